@@ -0,0 +1,252 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package canonical writes JSON in the form of the JSON Canonicalization Scheme (JCS, RFC 8785),
+// so that the same JSON value always results in the same bytes, for example before it is signed.
+package canonical
+
+import (
+	"io"
+	"math"
+	"slices"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/katydid/parser-go-json/json/internal/fork/strconv"
+	"github.com/katydid/parser-go-json/json/jsonschema"
+	jsonparse "github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/write"
+	"github.com/katydid/parser-go/cast"
+	"github.com/katydid/parser-go/parse"
+	"github.com/katydid/parser-go/pool"
+)
+
+// Canonicalize returns the canonical form of the JSON text, see Canonicalizer.
+func Canonicalize(src []byte) ([]byte, error) {
+	return NewCanonicalizer().Append(nil, src)
+}
+
+// Canonicalizer converts JSON text to its canonical form, as defined by RFC 8785:
+//   - whitespace is removed,
+//   - object members are sorted by their keys, compared as UTF-16 code units,
+//   - strings are written with as few escapes as possible and
+//   - numbers are written as ECMAScript formats IEEE 754 doubles.
+//
+// The input has to be I-JSON (RFC 7493), except that numbers are not limited to safe integers,
+// but are rounded to the closest double.
+// Strings with invalid UTF-8 or unpaired surrogates and objects with duplicate keys are rejected.
+//
+// A Canonicalizer reuses its memory, so that it does not allocate after warm-up.
+type Canonicalizer struct {
+	parser jsonparse.Parser
+	pool   pool.Pool
+	// keys contains the unquoted keys of the members of the objects that are being canonicalized.
+	keys []byte
+	// members is a stack of the members of the objects that are being canonicalized.
+	members []member
+	// tmp is used to reorder the members of an object.
+	tmp []byte
+}
+
+// member is a canonicalized object member, with its key in keys and its key and value in the output.
+type member struct {
+	keyStart int
+	keyEnd   int
+	start    int
+	end      int
+}
+
+// NewCanonicalizer returns a new Canonicalizer.
+func NewCanonicalizer() *Canonicalizer {
+	p := pool.New()
+	return &Canonicalizer{
+		parser: jsonparse.NewParser(jsonparse.WithAllocator(p.Alloc), jsonparse.WithStrictUTF8(), jsonparse.WithRejectDuplicateKeys()),
+		pool:   p,
+	}
+}
+
+// Append appends the canonical form of the JSON text in src to dst.
+func (c *Canonicalizer) Append(dst []byte, src []byte) ([]byte, error) {
+	c.parser.Init(src)
+	c.pool.FreeAll()
+	c.keys = c.keys[:0]
+	c.members = c.members[:0]
+	hint, err := c.parser.Next()
+	if err != nil {
+		return dst, err
+	}
+	dst, err = c.value(dst, hint)
+	if err != nil {
+		return dst, err
+	}
+	if _, err := c.parser.Next(); err != io.EOF {
+		return dst, err
+	}
+	return dst, nil
+}
+
+func (c *Canonicalizer) value(dst []byte, hint parse.Hint) ([]byte, error) {
+	switch hint {
+	case parse.ValueHint:
+		kind, value, err := c.parser.Token()
+		if err != nil {
+			return dst, err
+		}
+		return appendToken(dst, kind, value)
+	case parse.EnterHint:
+		if c.parser.JSONSchemaType() == jsonschema.JSONSchemaTypeArray {
+			return c.array(dst)
+		}
+		return c.object(dst)
+	}
+	return dst, errUnexpectedHint
+}
+
+func (c *Canonicalizer) array(dst []byte) ([]byte, error) {
+	dst = append(dst, '[')
+	for i := 0; ; i++ {
+		hint, err := c.parser.Next()
+		if err != nil {
+			return dst, err
+		}
+		if hint == parse.LeaveHint {
+			break
+		}
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		if dst, err = c.value(dst, hint); err != nil {
+			return dst, err
+		}
+	}
+	return append(dst, ']'), nil
+}
+
+// object appends the members of the object to dst in the order that they are parsed,
+// after which they are sorted by key and copied into their canonical order.
+func (c *Canonicalizer) object(dst []byte) ([]byte, error) {
+	dst = append(dst, '{')
+	start := len(dst)
+	membersBase := len(c.members)
+	keysBase := len(c.keys)
+	for {
+		hint, err := c.parser.Next()
+		if err != nil {
+			return dst, err
+		}
+		if hint == parse.LeaveHint {
+			break
+		}
+		_, key, err := c.parser.Token()
+		if err != nil {
+			return dst, err
+		}
+		m := member{keyStart: len(c.keys), start: len(dst)}
+		c.keys = append(c.keys, key...)
+		m.keyEnd = len(c.keys)
+		dst = write.AppendString(dst, key)
+		dst = append(dst, ':')
+		if hint, err = c.parser.Next(); err != nil {
+			return dst, err
+		}
+		if dst, err = c.value(dst, hint); err != nil {
+			return dst, err
+		}
+		m.end = len(dst)
+		c.members = append(c.members, m)
+	}
+	members := c.members[membersBase:]
+	slices.SortFunc(members, func(a, b member) int {
+		return compareUTF16(c.keys[a.keyStart:a.keyEnd], c.keys[b.keyStart:b.keyEnd])
+	})
+	c.tmp = append(c.tmp[:0], dst[start:]...)
+	dst = dst[:start]
+	for i, m := range members {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = append(dst, c.tmp[m.start-start:m.end-start]...)
+	}
+	c.members = c.members[:membersBase]
+	c.keys = c.keys[:keysBase]
+	return append(dst, '}'), nil
+}
+
+// compareUTF16 compares two valid UTF-8 strings, as if they were encoded as UTF-16 code units.
+// This differs from comparing the UTF-8 bytes, for characters above U+FFFF, which are encoded as surrogates (U+D800 to U+DFFF),
+// and are therefore ordered before characters from U+E000 to U+FFFF.
+func compareUTF16(a, b []byte) int {
+	for len(a) > 0 && len(b) > 0 {
+		ra, na := utf8.DecodeRune(a)
+		rb, nb := utf8.DecodeRune(b)
+		if ra != rb {
+			ua, ub := utf16Units(ra), utf16Units(rb)
+			if ua < ub {
+				return -1
+			}
+			return 1
+		}
+		a = a[na:]
+		b = b[nb:]
+	}
+	return len(a) - len(b)
+}
+
+// utf16Units returns the UTF-16 code units of the rune, with the first code unit in the upper 16 bits,
+// so that the results can be compared in the order of their code units.
+func utf16Units(r rune) uint32 {
+	if r < 0x10000 {
+		return uint32(r) << 16
+	}
+	hi, lo := utf16.EncodeRune(r)
+	return uint32(hi)<<16 | uint32(lo)
+}
+
+func appendToken(dst []byte, kind parse.Kind, value []byte) ([]byte, error) {
+	switch kind {
+	case parse.NullKind:
+		return append(dst, "null"...), nil
+	case parse.FalseKind:
+		return append(dst, "false"...), nil
+	case parse.TrueKind:
+		return append(dst, "true"...), nil
+	case parse.StringKind:
+		return write.AppendString(dst, value), nil
+	case parse.Int64Kind:
+		return appendNumber(dst, float64(cast.ToInt64(value)))
+	case parse.Float64Kind:
+		return appendNumber(dst, cast.ToFloat64(value))
+	case parse.DecimalKind:
+		// Decimals are integers or floats that do not fit into an int64 or float64,
+		// which are rounded to the closest double, as ECMAScript would.
+		f, err := strconv.ParseFloat(value)
+		if err != nil && !math.IsInf(f, 0) {
+			return dst, err
+		}
+		return appendNumber(dst, f)
+	}
+	return dst, errUnexpectedKind
+}
+
+// appendNumber appends the number as ECMAScript's Number.prototype.toString formats it,
+// which is the same as write.AppendFloat, except that negative zero is written as 0.
+func appendNumber(dst []byte, f float64) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return dst, ErrNotFinite
+	}
+	if f == 0 {
+		return append(dst, '0'), nil
+	}
+	return write.AppendFloat(dst, f), nil
+}
@@ -0,0 +1,196 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package canonical
+
+import (
+	"errors"
+	"math"
+	gostrconv "strconv"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/parse"
+)
+
+func expectCanonical(t *testing.T, input, want string) {
+	t.Helper()
+	got, err := Canonicalize([]byte(input))
+	if err != nil {
+		t.Fatalf("%s: %v", input, err)
+	}
+	if string(got) != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+}
+
+// TestRFC8785Example is the example of RFC 8785, section 3.2.2.
+func TestRFC8785Example(t *testing.T) {
+	input := `{
+  "numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+  "literals": [null, true, false]
+}`
+	want := `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`
+	expectCanonical(t, input, want)
+}
+
+// TestRFC8785Sorting is the example of RFC 8785, section 3.2.3.
+func TestRFC8785Sorting(t *testing.T) {
+	input := `{
+  "\u20ac": "Euro Sign",
+  "\r": "Carriage Return",
+  "\ufb33": "Hebrew Letter Dalet With Dagesh",
+  "1": "One",
+  "\ud83d\ude00": "Emoji: Grinning Face",
+  "\u0080": "Control",
+  "\u00f6": "Latin Small Letter O With Diaeresis"
+}`
+	want := "{" +
+		`"\r":"Carriage Return",` +
+		`"1":"One",` +
+		"\"\u0080\":\"Control\"," +
+		"\"\u00f6\":\"Latin Small Letter O With Diaeresis\"," +
+		"\"\u20ac\":\"Euro Sign\"," +
+		"\"\U0001F600\":\"Emoji: Grinning Face\"," +
+		"\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"" +
+		"}"
+	expectCanonical(t, input, want)
+}
+
+// TestRFC8785Numbers are the number test vectors of RFC 8785, appendix B.
+func TestRFC8785Numbers(t *testing.T) {
+	vectors := []struct {
+		bits uint64
+		want string
+	}{
+		{0x0000000000000000, "0"},
+		{0x8000000000000000, "0"},
+		{0x0000000000000001, "5e-324"},
+		{0x8000000000000001, "-5e-324"},
+		{0x7fefffffffffffff, "1.7976931348623157e+308"},
+		{0xffefffffffffffff, "-1.7976931348623157e+308"},
+		{0x4340000000000000, "9007199254740992"},
+		{0xc340000000000000, "-9007199254740992"},
+		{0x4430000000000000, "295147905179352830000"},
+		{0x44b52d02c7e14af5, "9.999999999999997e+22"},
+		{0x44b52d02c7e14af6, "1e+23"},
+		{0x44b52d02c7e14af7, "1.0000000000000001e+23"},
+		{0x444b1ae4d6e2ef4e, "999999999999999700000"},
+		{0x444b1ae4d6e2ef4f, "999999999999999900000"},
+		{0x444b1ae4d6e2ef50, "1e+21"},
+		{0x3eb0c6f7a0b5ed8c, "9.999999999999997e-7"},
+		{0x3eb0c6f7a0b5ed8d, "0.000001"},
+		{0x41b3de4355555553, "333333333.3333332"},
+		{0x41b3de4355555554, "333333333.33333325"},
+		{0x41b3de4355555555, "333333333.3333333"},
+		{0x41b3de4355555556, "333333333.3333334"},
+		{0x41b3de4355555557, "333333333.33333343"},
+		{0xbecbf647612f3696, "-0.0000033333333333333333"},
+		{0x43143ff3c1cb0959, "1424953923781206.2"},
+	}
+	for _, v := range vectors {
+		f := math.Float64frombits(v.bits)
+		input := gostrconv.FormatFloat(f, 'g', -1, 64)
+		expectCanonical(t, input, v.want)
+		got, err := appendNumber(nil, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != v.want {
+			t.Fatalf("%x: want %s, got %s", v.bits, v.want, got)
+		}
+	}
+	for _, bits := range []uint64{0x7fffffffffffffff, 0x7ff0000000000000} {
+		if _, err := appendNumber(nil, math.Float64frombits(bits)); !errors.Is(err, ErrNotFinite) {
+			t.Fatalf("%x: want ErrNotFinite, got %v", bits, err)
+		}
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{` null `, `null`},
+		{`[]`, `[]`},
+		{`{}`, `{}`},
+		{`-0`, `0`},
+		{`-0.0`, `0`},
+		{`100`, `100`},
+		{`1e2`, `100`},
+		{`9007199254740993`, `9007199254740992`},
+		{`18446744073709551615`, `18446744073709552000`},
+		{`[ {"b" : 1 , "a" : [ {"d":[],"c":{}} ]} , 2 ]`, `[{"a":[{"c":{},"d":[]}],"b":1},2]`},
+		{`{"b":{"z":1,"y":2},"a":{"x":{"w":3,"v":4}}}`, `{"a":{"x":{"v":4,"w":3}},"b":{"y":2,"z":1}}`},
+		{`{"aa":1,"a":2,"":3}`, `{"":3,"a":2,"aa":1}`},
+		{`"  \u007f"`, "\"  \u007f\""},
+	}
+	for _, test := range tests {
+		expectCanonical(t, test.input, test.want)
+	}
+}
+
+func TestCanonicalizeErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  error
+	}{
+		{`1e400`, ErrNotFinite},
+		{`{"a":1,"a":2}`, parse.ErrDuplicateKey},
+		{`"\ud800"`, parse.ErrLoneSurrogate},
+		{"\"\xff\"", parse.ErrInvalidUTF8},
+	}
+	for _, test := range tests {
+		if _, err := Canonicalize([]byte(test.input)); !errors.Is(err, test.want) {
+			t.Fatalf("%s: want %v, got %v", test.input, test.want, err)
+		}
+	}
+	if _, err := Canonicalize([]byte(`{"a":1}}`)); err == nil {
+		t.Fatal("expected error for trailing input")
+	}
+}
+
+func TestCompareUTF16(t *testing.T) {
+	// U+1F600 is encoded as the surrogates U+D83D U+DE00, which are ordered before U+FB33.
+	if compareUTF16([]byte("\U0001F600"), []byte("דּ")) >= 0 {
+		t.Fatal("want U+1F600 < U+FB33")
+	}
+	if compareUTF16([]byte("a"), []byte("ab")) >= 0 {
+		t.Fatal("want a < ab")
+	}
+	if compareUTF16([]byte("ab"), []byte("ab")) != 0 {
+		t.Fatal("want ab == ab")
+	}
+}
+
+func TestCanonicalizerNoAllocs(t *testing.T) {
+	c := NewCanonicalizer()
+	input := []byte(`{"b":[1,2.5,"é",{"d":null,"c":true}],"a":{"y":"x\nz","x":-0}}`)
+	var dst []byte
+	var err error
+	dst, err = c.Append(dst[:0], input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		dst, err = c.Append(dst[:0], input)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocs != 0 {
+		t.Fatalf("want 0 allocs, got %v", allocs)
+	}
+}
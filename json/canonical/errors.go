@@ -0,0 +1,28 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package canonical
+
+import (
+	"errors"
+
+	"github.com/katydid/parser-go-json/json/write"
+)
+
+// ErrNotFinite is returned for numbers that are too large to be represented by an IEEE 754 double, for example 1e400.
+var ErrNotFinite = write.ErrNotFinite
+
+var errUnexpectedHint = errors.New("unexpected hint")
+
+var errUnexpectedKind = errors.New("unexpected kind")
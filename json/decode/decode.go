@@ -0,0 +1,694 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package decode unmarshals the values that are returned by any parse.Parser into Go values, using reflection.
+package decode
+
+import (
+	"encoding"
+	"encoding/base64"
+	"io"
+	"reflect"
+
+	"github.com/katydid/parser-go-json/json/internal/fork/strconv"
+	"github.com/katydid/parser-go-json/json/internal/fork/unquote"
+	"github.com/katydid/parser-go-json/json/jsonschema"
+	"github.com/katydid/parser-go/cast"
+	"github.com/katydid/parser-go/parse"
+)
+
+// Unmarshal walks the parser, using Next, Skip and Token, and stores the value that it parses in the value that v points to.
+// It returns an error if the parser has any input left after the value.
+//
+// Values are stored following the rules of encoding/json:
+//   - struct fields are matched by the name in their json tag or otherwise their field name,
+//     preferring an exact match, but also accepting a case-insensitive match,
+//   - the "string" tag option expects a number, bool or string to be encoded inside a JSON string
+//     and only accepts null without quotes,
+//   - integers only accept numbers that are written as integers, so 1e2 and 1.0 cannot be stored in an int,
+//   - the "omitempty" tag option is accepted, but only matters for encoding,
+//   - fields that are unknown or have a "-" json tag are skipped using Skip,
+//   - JSON numbers are stored in an interface value as float64 and objects as map[string]any,
+//   - null sets pointers, interfaces, maps and slices to nil and leaves other values unchanged and
+//   - types that implement encoding.TextUnmarshaler are unmarshaled from strings.
+//
+// Unmarshal differs from encoding/json in that:
+//   - it returns the first error right away, where encoding/json continues to store the rest of the value after a *TypeError,
+//   - -0 is stored in an unsigned integer, since the parser returns it as the integer 0, which encoding/json rejects and
+//   - integers are only recognized if the parser returns them as parse.Int64Kind or parse.DecimalKind,
+//     since a parse.Float64Kind does not tell whether the number was written as an integer.
+//
+// The object and array tags of tag.WithTags and the array indexes of tag.WithIndexes are understood,
+// so Unmarshal works with any of the parsers in the json package.
+//
+// The decoding plans of struct types are cached and existing memory is reused:
+// non-nil pointers, maps and the capacity of slices are reused
+// and strings are only copied from the parser if they are different from the current value of the string,
+// so that decoding into a struct that was decoded into before does not allocate.
+func Unmarshal(p parse.Parser, v any) error {
+	if err := UnmarshalValue(p, v); err != nil {
+		return err
+	}
+	if _, err := p.Next(); err != io.EOF {
+		if err == nil {
+			return ErrUnexpectedHint
+		}
+		return err
+	}
+	return nil
+}
+
+// UnmarshalValue stores the next value of the parser in the value that v points to, see Unmarshal.
+func UnmarshalValue(p parse.Parser, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return ErrInvalidTarget
+	}
+	hint, err := p.Next()
+	if err != nil {
+		return err
+	}
+	return decode(p, rv.Elem(), hint, false)
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+func decode(p parse.Parser, v reflect.Value, hint parse.Hint, quoted bool) error {
+	switch hint {
+	case parse.ValueHint:
+		tok, err := readToken(p)
+		if err != nil {
+			return err
+		}
+		if tok.kind == parse.NullKind {
+			switch v.Kind() {
+			case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+				v.SetZero()
+			}
+			return nil
+		}
+		return decodeToken(indirect(v), tok, quoted)
+	case parse.EnterHint:
+		return decodeEnter(p, indirect(v))
+	}
+	return ErrUnexpectedHint
+}
+
+// token is the current token of the parser.
+type token struct {
+	kind  parse.Kind
+	value []byte
+	// int is the value of an int64 or nanoseconds token.
+	int int64
+	// float is the value of a float64 token.
+	float float64
+}
+
+// readToken returns the current token of the parser.
+// The value of an int64 or float64 token is converted right away,
+// since its bytes may refer to memory that is reused by the next function call, see cast.FromFloat64.
+func readToken(p parse.Parser) (token, error) {
+	kind, value, err := p.Token()
+	if err != nil {
+		return token{}, err
+	}
+	tok := token{kind: kind, value: value}
+	switch kind {
+	case parse.Int64Kind, parse.NanosecondsKind:
+		tok.int = cast.ToInt64(value)
+	case parse.Float64Kind:
+		tok.float = cast.ToFloat64(value)
+	}
+	return tok, nil
+}
+
+// indirect follows pointers, allocating new values for nil pointers.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func decodeToken(v reflect.Value, tok token, quoted bool) error {
+	kind, value := tok.kind, tok.value
+	if kind == parse.StringKind {
+		if v.CanAddr() && v.Kind() != reflect.Interface && reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+			return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText(value)
+		}
+		if quoted {
+			return decodeQuoted(v, value)
+		}
+	} else if quoted {
+		// Only null may be unquoted, which is handled before decodeToken is called.
+		return &TypeError{Value: "unquoted " + kind.String(), Type: v.Type()}
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			break
+		}
+		x, err := tokenValue(tok)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(x))
+		return nil
+	case reflect.Bool:
+		switch kind {
+		case parse.TrueKind:
+			v.SetBool(true)
+			return nil
+		case parse.FalseKind:
+			v.SetBool(false)
+			return nil
+		}
+	case reflect.String:
+		if kind == parse.StringKind || kind == parse.BytesKind {
+			setString(v, value)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, ok := toInt64(tok); ok && !v.OverflowInt(i) {
+			v.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u, ok := toUint64(tok); ok && !v.OverflowUint(u) {
+			v.SetUint(u)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, ok := toFloat64(tok); ok && !v.OverflowFloat(f) {
+			v.SetFloat(f)
+			return nil
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		switch kind {
+		case parse.StringKind:
+			// Bytes are encoded as a base64 string, like encoding/json does.
+			b, err := base64.StdEncoding.AppendDecode(v.Bytes()[:0], value)
+			if err != nil {
+				return err
+			}
+			v.SetBytes(b)
+			return nil
+		case parse.BytesKind:
+			v.SetBytes(append(v.Bytes()[:0], value...))
+			return nil
+		}
+	}
+	return &TypeError{Value: kind.String(), Type: v.Type()}
+}
+
+// setString only copies the value into a new string, if it is different from the current string.
+func setString(v reflect.Value, value []byte) {
+	if v.String() != string(value) {
+		v.SetString(string(value))
+	}
+}
+
+// decodeQuoted decodes a value of a field with the "string" option, which is encoded inside a JSON string.
+func decodeQuoted(v reflect.Value, value []byte) error {
+	if string(value) == "null" {
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		if len(value) == 0 || value[0] != '"' {
+			break
+		}
+		s, offset, ok := unquote.Unquote(allocBytes, value)
+		if !ok || offset != len(value) {
+			break
+		}
+		setString(v, s)
+		return nil
+	case reflect.Bool:
+		switch string(value) {
+		case "true":
+			v.SetBool(true)
+			return nil
+		case "false":
+			v.SetBool(false)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(value); err == nil && !v.OverflowInt(i) {
+			v.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u, err := strconv.ParseUint(value); err == nil && !v.OverflowUint(u) {
+			v.SetUint(u)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(value); err == nil && !v.OverflowFloat(f) {
+			v.SetFloat(f)
+			return nil
+		}
+	}
+	return &TypeError{Value: "string", Type: v.Type()}
+}
+
+func allocBytes(size int) []byte {
+	return make([]byte, size)
+}
+
+// toInt64 returns the value of an integer token.
+// Floats are never integers, even if they have no fraction, since the parser returns numbers that are written as integers as integers,
+// so, like encoding/json, 1e2 is not stored in an int.
+func toInt64(tok token) (int64, bool) {
+	switch tok.kind {
+	case parse.Int64Kind, parse.NanosecondsKind:
+		return tok.int, true
+	case parse.DecimalKind:
+		i, err := strconv.ParseInt(tok.value)
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// toUint64 returns the value of a non-negative integer token, see toInt64.
+func toUint64(tok token) (uint64, bool) {
+	switch tok.kind {
+	case parse.Int64Kind:
+		return uint64(tok.int), tok.int >= 0
+	case parse.DecimalKind:
+		u, err := strconv.ParseUint(tok.value)
+		return u, err == nil
+	}
+	return 0, false
+}
+
+func toFloat64(tok token) (float64, bool) {
+	switch tok.kind {
+	case parse.Int64Kind:
+		return float64(tok.int), true
+	case parse.Float64Kind:
+		return tok.float, true
+	case parse.DecimalKind:
+		f, err := strconv.ParseFloat(tok.value)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// tokenValue returns the value of the token, as it is stored in an empty interface.
+func tokenValue(tok token) (any, error) {
+	switch tok.kind {
+	case parse.NullKind:
+		return nil, nil
+	case parse.TrueKind:
+		return true, nil
+	case parse.FalseKind:
+		return false, nil
+	case parse.StringKind:
+		return string(tok.value), nil
+	case parse.BytesKind:
+		return append([]byte(nil), tok.value...), nil
+	case parse.Int64Kind, parse.Float64Kind, parse.DecimalKind:
+		if f, ok := toFloat64(tok); ok {
+			return f, nil
+		}
+	case parse.NanosecondsKind:
+		return tok.int, nil
+	}
+	return nil, &TypeError{Value: tok.kind.String(), Type: reflect.TypeFor[any]()}
+}
+
+// enter is called after an EnterHint and returns the first hint inside the object or array and whether it is an object or an array.
+// If the object or array is tagged, see tag.WithTags, the parser is moved into the tagged object or array
+// and leave needs to be called at its end.
+// An empty object or array is of unknown type, unless the parser is jsonschema.JSONSchemaAble.
+func enter(p parse.Parser) (parse.Hint, jsonschema.JSONSchemaType, bool, error) {
+	typ := jsonschema.JSONSchemaTypeUnknown
+	if s, ok := p.(jsonschema.JSONSchemaAble); ok {
+		typ = s.JSONSchemaType()
+	}
+	hint, err := p.Next()
+	if err != nil {
+		return hint, typ, false, err
+	}
+	switch hint {
+	case parse.FieldHint:
+		kind, name, err := p.Token()
+		if err != nil {
+			return hint, typ, false, err
+		}
+		switch {
+		case kind == parse.TagKind && typ != jsonschema.JSONSchemaTypeArray:
+			switch string(name) {
+			case "object":
+				typ = jsonschema.JSONSchemaTypeObject
+			case "array":
+				typ = jsonschema.JSONSchemaTypeArray
+			default:
+				return hint, typ, false, ErrUnexpectedHint
+			}
+			if hint, err = p.Next(); err != nil {
+				return hint, typ, false, err
+			}
+			if hint != parse.EnterHint {
+				return hint, typ, false, ErrUnexpectedHint
+			}
+			hint, err = p.Next()
+			return hint, typ, true, err
+		case kind == parse.Int64Kind && typ != jsonschema.JSONSchemaTypeObject:
+			typ = jsonschema.JSONSchemaTypeArray
+		default:
+			typ = jsonschema.JSONSchemaTypeObject
+		}
+	case parse.ValueHint, parse.EnterHint:
+		typ = jsonschema.JSONSchemaTypeArray
+	}
+	return hint, typ, false, nil
+}
+
+// leave leaves the object that wraps a tagged object or array.
+func leave(p parse.Parser) error {
+	hint, err := p.Next()
+	if err != nil {
+		return err
+	}
+	if hint != parse.LeaveHint {
+		return ErrUnexpectedHint
+	}
+	return nil
+}
+
+func decodeEnter(p parse.Parser, v reflect.Value) error {
+	if v.Kind() == reflect.Interface && v.NumMethod() == 0 {
+		x, err := enterValue(p)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(x))
+		return nil
+	}
+	hint, typ, tagged, err := enter(p)
+	if err != nil {
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if typ == jsonschema.JSONSchemaTypeArray {
+			return &TypeError{Value: "array", Type: v.Type()}
+		}
+		err = decodeStruct(p, v, hint)
+	case reflect.Map:
+		if typ == jsonschema.JSONSchemaTypeArray {
+			return &TypeError{Value: "array", Type: v.Type()}
+		}
+		err = decodeMap(p, v, hint)
+	case reflect.Slice:
+		if typ == jsonschema.JSONSchemaTypeObject {
+			return &TypeError{Value: "object", Type: v.Type()}
+		}
+		err = decodeSlice(p, v, hint)
+	case reflect.Array:
+		if typ == jsonschema.JSONSchemaTypeObject {
+			return &TypeError{Value: "object", Type: v.Type()}
+		}
+		err = decodeArray(p, v, hint)
+	default:
+		value := "object"
+		if typ == jsonschema.JSONSchemaTypeArray {
+			value = "array"
+		}
+		return &TypeError{Value: value, Type: v.Type()}
+	}
+	if err != nil {
+		return err
+	}
+	if tagged {
+		return leave(p)
+	}
+	return nil
+}
+
+// decodeStruct decodes the fields of an object into a struct, starting at the first hint after the object was entered.
+func decodeStruct(p parse.Parser, v reflect.Value, hint parse.Hint) error {
+	plan := planOf(v.Type())
+	for hint != parse.LeaveHint {
+		if hint != parse.FieldHint {
+			return ErrUnexpectedHint
+		}
+		kind, key, err := p.Token()
+		if err != nil {
+			return err
+		}
+		var f *field
+		if kind == parse.StringKind {
+			f = plan.lookup(key)
+		}
+		if f == nil {
+			if err := p.Skip(); err != nil {
+				return err
+			}
+		} else {
+			if hint, err = p.Next(); err != nil {
+				return err
+			}
+			fv, err := fieldByIndex(v, f.index)
+			if err != nil {
+				return err
+			}
+			if err := decode(p, fv, hint, f.quoted); err != nil {
+				return err
+			}
+		}
+		if hint, err = p.Next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldByIndex returns the nested field, allocating embedded structs that are nil pointers.
+// A nil pointer to an unexported embedded struct cannot be allocated, so an *EmbeddedPointerError is returned instead.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 {
+			if v.Kind() == reflect.Pointer && v.IsNil() && !v.CanSet() {
+				return reflect.Value{}, &EmbeddedPointerError{Type: v.Type().Elem()}
+			}
+			v = indirect(v)
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// decodeMap decodes the fields of an object into a map, starting at the first hint after the object was entered.
+// Map keys have to be strings or integers.
+func decodeMap(p parse.Parser, v reflect.Value, hint parse.Hint) error {
+	t := v.Type()
+	keyType := t.Key()
+	switch keyType.Kind() {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+	default:
+		return &TypeError{Value: "object", Type: t}
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(t))
+	}
+	elem := reflect.New(t.Elem()).Elem()
+	key := reflect.New(keyType).Elem()
+	for hint != parse.LeaveHint {
+		if hint != parse.FieldHint {
+			return ErrUnexpectedHint
+		}
+		_, name, err := p.Token()
+		if err != nil {
+			return err
+		}
+		if keyType.Kind() == reflect.String {
+			key.SetString(string(name))
+		} else if err := decodeQuoted(key, name); err != nil {
+			return err
+		}
+		if hint, err = p.Next(); err != nil {
+			return err
+		}
+		elem.SetZero()
+		if err := decode(p, elem, hint, false); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		if hint, err = p.Next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeSlice decodes the elements of an array into a slice, starting at the first hint after the array was entered.
+// The slice is reset to zero length and each element is appended, reusing the capacity of the slice.
+func decodeSlice(p parse.Parser, v reflect.Value, hint parse.Hint) error {
+	i := 0
+	var err error
+	for hint != parse.LeaveHint {
+		if hint == parse.FieldHint {
+			// Skip over the index.
+			if hint, err = p.Next(); err != nil {
+				return err
+			}
+		}
+		if i >= v.Cap() {
+			v.Grow(1)
+		}
+		if i >= v.Len() {
+			v.SetLen(i + 1)
+		}
+		if err := decode(p, v.Index(i), hint, false); err != nil {
+			return err
+		}
+		i++
+		if hint, err = p.Next(); err != nil {
+			return err
+		}
+	}
+	if i < v.Len() {
+		v.SetLen(i)
+	}
+	if v.IsNil() {
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+	}
+	return nil
+}
+
+// decodeArray decodes the elements of an array into a Go array, starting at the first hint after the array was entered.
+// Extra elements are skipped and missing elements are set to zero.
+func decodeArray(p parse.Parser, v reflect.Value, hint parse.Hint) error {
+	i := 0
+	var err error
+	for hint != parse.LeaveHint {
+		if hint == parse.FieldHint {
+			// Skip over the index.
+			if hint, err = p.Next(); err != nil {
+				return err
+			}
+		}
+		if i < v.Len() {
+			if err := decode(p, v.Index(i), hint, false); err != nil {
+				return err
+			}
+		} else if hint == parse.EnterHint {
+			if err := p.Skip(); err != nil {
+				return err
+			}
+		}
+		i++
+		if hint, err = p.Next(); err != nil {
+			return err
+		}
+	}
+	for ; i < v.Len(); i++ {
+		v.Index(i).SetZero()
+	}
+	return nil
+}
+
+// enterValue is called after an EnterHint and returns the object or array as a map[string]any or []any.
+func enterValue(p parse.Parser) (any, error) {
+	hint, typ, tagged, err := enter(p)
+	if err != nil {
+		return nil, err
+	}
+	var x any
+	if typ == jsonschema.JSONSchemaTypeArray {
+		x, err = arrayValue(p, hint)
+	} else {
+		x, err = objectValue(p, hint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tagged {
+		if err := leave(p); err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+func value(p parse.Parser, hint parse.Hint) (any, error) {
+	switch hint {
+	case parse.ValueHint:
+		tok, err := readToken(p)
+		if err != nil {
+			return nil, err
+		}
+		return tokenValue(tok)
+	case parse.EnterHint:
+		return enterValue(p)
+	}
+	return nil, ErrUnexpectedHint
+}
+
+func objectValue(p parse.Parser, hint parse.Hint) (map[string]any, error) {
+	m := make(map[string]any)
+	for hint != parse.LeaveHint {
+		if hint != parse.FieldHint {
+			return nil, ErrUnexpectedHint
+		}
+		_, name, err := p.Token()
+		if err != nil {
+			return nil, err
+		}
+		key := string(name)
+		if hint, err = p.Next(); err != nil {
+			return nil, err
+		}
+		if m[key], err = value(p, hint); err != nil {
+			return nil, err
+		}
+		if hint, err = p.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func arrayValue(p parse.Parser, hint parse.Hint) ([]any, error) {
+	a := make([]any, 0)
+	var err error
+	for hint != parse.LeaveHint {
+		if hint == parse.FieldHint {
+			// Skip over the index.
+			if hint, err = p.Next(); err != nil {
+				return nil, err
+			}
+		}
+		x, err := value(p, hint)
+		if err != nil {
+			return nil, err
+		}
+		a = append(a, x)
+		if hint, err = p.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
@@ -0,0 +1,384 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package decode
+
+import (
+	gojson "encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/katydid/parser-go-json/json"
+	jsonparse "github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/tag"
+	"github.com/katydid/parser-go/parse"
+)
+
+type Embedded struct {
+	E     string
+	Shade int `json:"shade"`
+}
+
+type Inner struct {
+	Name  string   `json:"name"`
+	Tags  []string `json:"tags,omitempty"`
+	Score float64  `json:"score"`
+}
+
+type Outer struct {
+	Embedded
+	*Inner  `json:"inner"`
+	ID      uint64            `json:"id"`
+	Count   int8              `json:"count,string"`
+	OK      bool              `json:"ok,string"`
+	Quoted  string            `json:"quoted,string"`
+	Ignored string            `json:"-"`
+	Renamed string            `json:"renamed"`
+	Folded  string            `json:"folded"`
+	Items   []Inner           `json:"items"`
+	Matrix  [][]int           `json:"matrix"`
+	Pair    [2]int            `json:"pair"`
+	Map     map[string]int    `json:"map"`
+	IntMap  map[int]string    `json:"intmap"`
+	Any     any               `json:"any"`
+	Ptr     *int              `json:"ptr"`
+	Null    *int              `json:"null"`
+	Bytes   []byte            `json:"bytes"`
+	Time    time.Time         `json:"time"`
+	Nested  map[string][]bool `json:"nested"`
+	Shade   string            `json:"shade"`
+	private int
+}
+
+const outerInput = `{
+	"E": "embedded",
+	"shade": "outer wins",
+	"inner": {"name": "in", "tags": ["a", "b"], "score": 1.5},
+	"id": 18446744073709551615,
+	"count": "-12",
+	"ok": "true",
+	"quoted": "\"q\\n\"",
+	"-": "ignored",
+	"Ignored": "ignored",
+	"renamed": "r",
+	"FOLDED": "f",
+	"unknown": {"a": [1, 2, {"b": null}]},
+	"items": [{"name": "x"}, {"name": "y", "score": -2e3}],
+	"matrix": [[], [1], [1, 2]],
+	"pair": [3, 4, 5],
+	"map": {"a": 1, "b": 2},
+	"intmap": {"1": "one", "-2": "minus two"},
+	"any": {"a": [1, "b", true, null, {}], "c": 1.25},
+	"ptr": 7,
+	"null": null,
+	"bytes": "aGVsbG8=",
+	"time": "2025-01-02T03:04:05Z",
+	"nested": {"x": [true, false], "y": []},
+	"private": 1
+}`
+
+var parsers = map[string]func(input []byte) parse.Parser{
+	"parse": func(input []byte) parse.Parser {
+		return jsonparse.NewParser(jsonparse.WithBuffer(input))
+	},
+	"indexes": func(input []byte) parse.Parser {
+		p := json.NewParser()
+		p.Init(input)
+		return p
+	},
+	"jsonschema": func(input []byte) parse.Parser {
+		p := json.NewJSONSchemaParser()
+		p.Init(input)
+		return p
+	},
+	"tags": func(input []byte) parse.Parser {
+		return tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer(input)), tag.WithTags())
+	},
+}
+
+// expectSame unmarshals the input with each parser and with encoding/json and compares the results.
+func expectSame[T any](t *testing.T, input string) {
+	t.Helper()
+	var want T
+	if err := gojson.Unmarshal([]byte(input), &want); err != nil {
+		t.Fatal(err)
+	}
+	for name, newParser := range parsers {
+		var got T
+		if err := Unmarshal(newParser([]byte(input)), &got); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: want %#v, got %#v", name, want, got)
+		}
+	}
+}
+
+func TestUnmarshalStruct(t *testing.T) {
+	expectSame[Outer](t, outerInput)
+}
+
+func TestUnmarshalValues(t *testing.T) {
+	expectSame[any](t, `null`)
+	expectSame[any](t, `[]`)
+	expectSame[any](t, `{}`)
+	expectSame[any](t, `[{}, [], {"a": []}, [[{}]]]`)
+	expectSame[any](t, outerInput)
+	expectSame[[]int](t, `[]`)
+	expectSame[[]int](t, `[1, 2, 3]`)
+	expectSame[map[string]any](t, `{}`)
+	expectSame[[3]string](t, `["a"]`)
+	expectSame[*string](t, `"s"`)
+	expectSame[float32](t, `3.5`)
+	expectSame[int](t, `-9223372036854775808`)
+	expectSame[uint](t, `18446744073709551615`)
+	expectSame[float64](t, `1e-400`)
+	expectSame[Inner](t, `{}`)
+}
+
+func TestUnmarshalReuse(t *testing.T) {
+	v := Outer{Items: make([]Inner, 3, 10), Matrix: [][]int{{9, 9, 9}}, Map: map[string]int{"z": 26}}
+	items := v.Items
+	p := json.NewParser()
+	p.Init([]byte(outerInput))
+	if err := Unmarshal(p, &v); err != nil {
+		t.Fatal(err)
+	}
+	if &v.Items[0] != &items[0] {
+		t.Fatal("want the capacity of the slice to be reused")
+	}
+	if len(v.Items) != 2 {
+		t.Fatalf("want 2 items, got %d", len(v.Items))
+	}
+	if v.Map["z"] != 26 || v.Map["a"] != 1 {
+		t.Fatalf("want the map to be reused, got %v", v.Map)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	var typeErr *TypeError
+	tests := []struct {
+		input string
+		v     any
+		want  any
+	}{
+		{`"a"`, new(int), &typeErr},
+		{`-1`, new(uint), &typeErr},
+		{`1.5`, new(int), &typeErr},
+		{`256`, new(uint8), &typeErr},
+		{`1e300`, new(float32), &typeErr},
+		{`{}`, new([]int), &typeErr},
+		{`[1]`, new(Inner), &typeErr},
+		{`[1]`, new(map[string]int), &typeErr},
+		{`{"a":1}`, new(int), &typeErr},
+		{`{"count":"x"}`, new(Outer), &typeErr},
+		{`{"a":1}`, new(map[bool]int), &typeErr},
+		{`1`, 1, ErrInvalidTarget},
+		{`1`, (*int)(nil), ErrInvalidTarget},
+		{`1 2`, new(int), nil},
+	}
+	for _, test := range tests {
+		for name, newParser := range parsers {
+			err := Unmarshal(newParser([]byte(test.input)), test.v)
+			if err == nil {
+				t.Fatalf("%s: %s: expected error", name, test.input)
+			}
+			switch want := test.want.(type) {
+			case **TypeError:
+				if !errors.As(err, want) {
+					t.Fatalf("%s: %s: want *TypeError, got %v", name, test.input, err)
+				}
+			case error:
+				if !errors.Is(err, want) {
+					t.Fatalf("%s: %s: want %v, got %v", name, test.input, want, err)
+				}
+			}
+		}
+	}
+	if err := Unmarshal(json.NewParser(), new(int)); err == nil {
+		t.Fatal("expected error from a parser without input")
+	}
+}
+
+type QuotedFields struct {
+	Int   int      `json:"int,string"`
+	Uint  uint     `json:"uint,string"`
+	Float float64  `json:"float,string"`
+	Bool  bool     `json:"bool,string"`
+	Str   string   `json:"str,string"`
+	Ptr   *int     `json:"ptr,string"`
+	Slice []string `json:"slice,string"`
+}
+
+// expectLikeEncodingJSON unmarshals the input with each parser and with encoding/json
+// and checks that either both return an error or both return the same value.
+func expectLikeEncodingJSON[T any](t *testing.T, input string) {
+	t.Helper()
+	var want T
+	wantErr := gojson.Unmarshal([]byte(input), &want)
+	for name, newParser := range parsers {
+		var got T
+		gotErr := Unmarshal(newParser([]byte(input)), &got)
+		if (wantErr == nil) != (gotErr == nil) {
+			t.Fatalf("%s: %s: want error %v, got %v", name, input, wantErr, gotErr)
+		}
+		if wantErr == nil && !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: %s: want %#v, got %#v", name, input, want, got)
+		}
+	}
+}
+
+func TestUnmarshalLikeEncodingJSON(t *testing.T) {
+	numbers := []string{`0`, `-1`, `5`, `1e2`, `1E2`, `1.0`, `-0.0`, `1.5`, `1e-2`, `256`,
+		`9223372036854775807`, `9223372036854775808`, `-9223372036854775809`, `18446744073709551616`, `100000000000000000000`, `1e400`}
+	for _, number := range numbers {
+		expectLikeEncodingJSON[int](t, number)
+		expectLikeEncodingJSON[int8](t, number)
+		expectLikeEncodingJSON[uint](t, number)
+		expectLikeEncodingJSON[uint8](t, number)
+		expectLikeEncodingJSON[float32](t, number)
+		expectLikeEncodingJSON[float64](t, number)
+		expectLikeEncodingJSON[any](t, number)
+		for _, field := range []string{"int", "uint", "float"} {
+			expectLikeEncodingJSON[QuotedFields](t, `{"`+field+`":`+number+`}`)
+			expectLikeEncodingJSON[QuotedFields](t, `{"`+field+`":"`+number+`"}`)
+		}
+	}
+	quoted := []string{
+		`{"bool":true}`, `{"bool":"true"}`, `{"bool":"x"}`,
+		`{"str":"x"}`, `{"str":"\"x\""}`, `{"str":1}`,
+		`{"ptr":5}`, `{"ptr":"5"}`,
+		`{"int":null,"uint":null,"float":null,"bool":null,"str":null,"ptr":null}`,
+		`{"int":"null","bool":"null","str":"null"}`,
+		`{"slice":["a"]}`,
+		`{"int":[1]}`, `{"int":{}}`,
+	}
+	for _, input := range quoted {
+		expectLikeEncodingJSON[QuotedFields](t, input)
+	}
+}
+
+func TestUnmarshalValue(t *testing.T) {
+	p := json.NewParser()
+	p.Init([]byte(`[{"name":"a"},{"name":"b"}]`))
+	if _, err := p.Next(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a", "b"}
+	for i := range want {
+		if hint, err := p.Next(); err != nil || hint != parse.FieldHint {
+			t.Fatalf("want field, got %v, %v", hint, err)
+		}
+		var got Inner
+		if err := UnmarshalValue(p, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != want[i] {
+			t.Fatalf("want %s, got %s", want[i], got.Name)
+		}
+	}
+}
+
+type Point struct {
+	X, Y    float64
+	Label   string `json:"label"`
+	Visible bool   `json:"visible,omitempty"`
+}
+
+type Shape struct {
+	Name   string  `json:"name"`
+	Points []Point `json:"points"`
+	Size   int64   `json:"size,string"`
+	Scale  *Point  `json:"scale"`
+}
+
+func TestUnmarshalNoAllocs(t *testing.T) {
+	input := []byte(`{"name": "tri\nangle", "unknown": [1, {"a": "b"}], "size": "3",
+		"points": [{"X": 1, "Y": 2.5, "label": "aé"}, {"x": -1, "y": 0, "visible": true}, {"X": 1e3}],
+		"scale": {"X": 2, "Y": 2}}`)
+	p := json.NewParser()
+	var s Shape
+	unmarshal := func() {
+		p.Init(input)
+		if err := Unmarshal(p, &s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	unmarshal()
+	if s.Name != "tri\nangle" || len(s.Points) != 3 || s.Points[0].Label != "aé" || s.Size != 3 || s.Scale.X != 2 || s.Points[1].X != -1 {
+		t.Fatalf("unexpected result %#v", s)
+	}
+	if allocs := testing.AllocsPerRun(100, unmarshal); allocs != 0 {
+		t.Fatalf("want 0 allocs, got %v", allocs)
+	}
+}
+
+func TestPlanConflicts(t *testing.T) {
+	type A struct{ X, Y int }
+	type B struct {
+		X int
+		Y int `json:"Y"`
+	}
+	type C struct {
+		A
+		B
+	}
+	p := planOf(reflect.TypeFor[C]())
+	if f := p.lookup([]byte("X")); f != nil {
+		t.Fatalf("want the conflicting field X to be dropped, got %v", f.index)
+	}
+	if f := p.lookup([]byte("Y")); f == nil || !reflect.DeepEqual(f.index, []int{1, 1}) {
+		t.Fatalf("want the tagged field Y to dominate")
+	}
+	expectSame[C](t, `{"X": 1, "Y": 2}`)
+}
+
+type unexportedEmbedded struct{ X int }
+
+type EmbeddedPointer struct {
+	*unexportedEmbedded
+	Y int
+}
+
+func TestPlanUnexportedEmbeddedPointer(t *testing.T) {
+	if f := planOf(reflect.TypeFor[EmbeddedPointer]()).lookup([]byte("X")); f == nil {
+		t.Fatal("want the field X to be promoted")
+	}
+	var embeddedErr *EmbeddedPointerError
+	for name, newParser := range parsers {
+		var v EmbeddedPointer
+		if err := Unmarshal(newParser([]byte(`{"X":1}`)), &v); !errors.As(err, &embeddedErr) {
+			t.Fatalf("%s: want *EmbeddedPointerError, got %v", name, err)
+		}
+		// The pointer can still be followed, if it is not nil.
+		v = EmbeddedPointer{unexportedEmbedded: &unexportedEmbedded{}}
+		if err := Unmarshal(newParser([]byte(`{"X":1,"Y":2}`)), &v); err != nil || v.X != 1 || v.Y != 2 {
+			t.Fatalf("%s: want X 1 and Y 2, got %#v, %v", name, v, err)
+		}
+	}
+	expectLikeEncodingJSON[EmbeddedPointer](t, `{"X":1}`)
+	expectSame[EmbeddedPointer](t, `{"Y":2}`)
+}
+
+func TestUnmarshalSpecialFloats(t *testing.T) {
+	var f float64
+	p := json.NewParser()
+	p.Init([]byte(`1.7976931348623157e308`))
+	if err := Unmarshal(p, &f); err != nil || f != math.MaxFloat64 {
+		t.Fatalf("want MaxFloat64, got %v, %v", f, err)
+	}
+}
@@ -0,0 +1,50 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package decode
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrInvalidTarget is returned when the value that is passed to Unmarshal is not a non-nil pointer.
+var ErrInvalidTarget = errors.New("unmarshal target must be a non-nil pointer")
+
+// ErrUnexpectedHint is returned when the parser returns a hint that does not fit the structure of a JSON value.
+var ErrUnexpectedHint = errors.New("unexpected hint")
+
+// TypeError is returned when a value cannot be unmarshaled into the Go value, because it has the wrong type,
+// for example a string into an int or a negative number into a uint.
+type TypeError struct {
+	// Value describes the value, for example "object", "array" or the kind of the token, such as "string".
+	Value string
+	// Type is the type of the Go value.
+	Type reflect.Type
+}
+
+func (e *TypeError) Error() string {
+	return "cannot unmarshal " + e.Value + " into Go value of type " + e.Type.String()
+}
+
+// EmbeddedPointerError is returned when a field of a struct is promoted through a nil pointer to an unexported embedded struct,
+// which cannot be allocated, since unexported fields cannot be set.
+type EmbeddedPointerError struct {
+	// Type is the type of the unexported embedded struct.
+	Type reflect.Type
+}
+
+func (e *EmbeddedPointerError) Error() string {
+	return "cannot set embedded pointer to unexported struct " + e.Type.String()
+}
@@ -0,0 +1,182 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package decode
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// plans is a cache of the decoding plans of struct types, map[reflect.Type]*structPlan.
+var plans sync.Map
+
+// structPlan is the decoding plan of a struct type.
+type structPlan struct {
+	fields []field
+	// byName maps the exact names of the fields to their index in fields.
+	byName map[string]int
+}
+
+// field is a field of a struct, that is possibly promoted from an embedded struct.
+type field struct {
+	name []byte
+	// index is the sequence of field indexes, to reach the field from the outer struct, see reflect.Value.FieldByIndex.
+	index []int
+	// quoted is true if the field has the "string" option and therefore expects its value to be encoded inside a JSON string.
+	quoted bool
+}
+
+// planOf returns the cached decoding plan of the struct type, after creating it on first use.
+func planOf(t reflect.Type) *structPlan {
+	if p, ok := plans.Load(t); ok {
+		return p.(*structPlan)
+	}
+	p, _ := plans.LoadOrStore(t, newStructPlan(t))
+	return p.(*structPlan)
+}
+
+// lookup returns the field with the key as its name, preferring an exact match over a case-insensitive match, like encoding/json.
+func (p *structPlan) lookup(key []byte) *field {
+	if i, ok := p.byName[string(key)]; ok {
+		return &p.fields[i]
+	}
+	for i := range p.fields {
+		if bytes.EqualFold(p.fields[i].name, key) {
+			return &p.fields[i]
+		}
+	}
+	return nil
+}
+
+// newStructPlan lists the fields of the struct type, including the fields that are promoted from embedded structs,
+// using the same rules as encoding/json: fields at a shallower depth hide deeper fields with the same name,
+// and conflicting fields at the same depth are dropped, unless exactly one of them is named with a tag.
+func newStructPlan(t reflect.Type) *structPlan {
+	type candidate struct {
+		field
+		depth  int
+		tagged bool
+	}
+	var candidates []candidate
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	current := []embedded{{typ: t}}
+	visited := map[reflect.Type]bool{}
+	for depth := 0; len(current) > 0; depth++ {
+		var next []embedded
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				if sf.Anonymous {
+					ft := sf.Type
+					if ft.Kind() == reflect.Pointer {
+						ft = ft.Elem()
+					}
+					if !sf.IsExported() && ft.Kind() != reflect.Struct {
+						continue
+					}
+				} else if !sf.IsExported() {
+					continue
+				}
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				index := make([]int, len(e.index)+1)
+				copy(index, e.index)
+				index[len(e.index)] = i
+				ft := sf.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+					next = append(next, embedded{typ: ft, index: index})
+					continue
+				}
+				tagged := name != ""
+				if !tagged {
+					name = sf.Name
+				}
+				candidates = append(candidates, candidate{
+					field: field{
+						name:   []byte(name),
+						index:  index,
+						quoted: hasOption(opts, "string") && isQuotable(ft.Kind()),
+					},
+					depth:  depth,
+					tagged: tagged,
+				})
+			}
+		}
+		current = next
+	}
+	p := &structPlan{byName: make(map[string]int)}
+	for i := 0; i < len(candidates); i++ {
+		c := candidates[i]
+		// Find the dominant field among the candidates with the same name.
+		dominant, conflict := i, false
+		for j := range candidates {
+			if j == i || string(candidates[j].name) != string(c.name) {
+				continue
+			}
+			o := candidates[j]
+			if o.depth < candidates[dominant].depth || (o.depth == candidates[dominant].depth && o.tagged && !candidates[dominant].tagged) {
+				dominant, conflict = j, false
+			} else if o.depth == candidates[dominant].depth && o.tagged == candidates[dominant].tagged {
+				conflict = true
+			}
+		}
+		if dominant != i || conflict {
+			continue
+		}
+		p.byName[string(c.name)] = len(p.fields)
+		p.fields = append(p.fields, c.field)
+	}
+	return p
+}
+
+// hasOption reports whether the comma separated options of a json tag contain the option.
+// The omitempty option is accepted, but only affects encoding.
+func hasOption(opts string, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+// isQuotable reports whether the "string" option applies to a field of this kind.
+func isQuotable(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package json
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go/parse/debug"
+)
+
+func TestDefaultMaxDepth(t *testing.T) {
+	parsers := map[string]Parser{
+		"NewParser":           NewParser(),
+		"NewJSONSchemaParser": NewJSONSchemaParser(),
+	}
+	for name, p := range parsers {
+		t.Run(name, func(t *testing.T) {
+			p.Init([]byte(strings.Repeat("[", DefaultMaxDepth) + strings.Repeat("]", DefaultMaxDepth)))
+			if err := debug.Walk(p); err != nil {
+				t.Fatal(err)
+			}
+			p.Init([]byte(strings.Repeat("[", DefaultMaxDepth+1) + strings.Repeat("]", DefaultMaxDepth+1)))
+			if err := debug.Walk(p); !errors.Is(err, parse.ErrMaxDepthExceeded) {
+				t.Fatalf("expected %v, but got %v", parse.ErrMaxDepthExceeded, err)
+			}
+		})
+	}
+}
+
+func TestLinesParserMaxDepth(t *testing.T) {
+	p := NewLinesParser(parse.WithMaxDepth(2))
+	p.Init([]byte("[[1]]\n[[[1]]]\n"))
+	if err := debug.Walk(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.NextDocument(); err != nil {
+		t.Fatal(err)
+	}
+	if err := debug.Walk(p); !errors.Is(err, parse.ErrMaxDepthExceeded) {
+		t.Fatalf("expected %v, but got %v", parse.ErrMaxDepthExceeded, err)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/rand"
+	goparse "github.com/katydid/parser-go/parse"
+	"github.com/katydid/parser-go/parse/debug"
+)
+
+// fedParser feeds the parser one byte at a time, whenever it needs more input.
+type fedParser struct {
+	Parser
+	input []byte
+}
+
+func (p *fedParser) Next() (goparse.Hint, error) {
+	for {
+		hint, err := p.Parser.Next()
+		if err != parse.ErrNeedMoreInput {
+			return hint, err
+		}
+		p.feed()
+	}
+}
+
+func (p *fedParser) feed() {
+	if len(p.input) == 0 {
+		p.Close()
+		return
+	}
+	p.Feed(p.input[:1])
+	p.input = p.input[1:]
+}
+
+func TestFeedNextBeforeFirstChunk(t *testing.T) {
+	p := NewParser()
+	if _, err := p.Next(); err != parse.ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	p.Feed([]byte(`{"a":`))
+	if hint, err := p.Next(); err != nil || hint != goparse.EnterHint {
+		t.Fatalf("expected enter, but got %v %v", hint, err)
+	}
+	p.Close()
+	if _, err := debug.Parse(p); err == nil {
+		t.Fatalf("expected an error for the unfinished object")
+	}
+}
+
+func TestFeedSameAsInit(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	want := NewParser()
+	got := NewParser()
+	for _, value := range values {
+		t.Run(testrun.Name(value), func(t *testing.T) {
+			want.Init(value)
+			got.Init(nil)
+			got.Feed(nil)
+			expectSameWalk(t, want, &fedParser{Parser: got, input: value})
+		})
+	}
+}
@@ -0,0 +1,278 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package format reformats JSON text, without decoding it, by copying the tokens that are scanned by scan.Scanner.
+// Tokens are copied exactly as they are written in the input, so that, for example, the spelling of numbers and the escapes in strings are preserved.
+package format
+
+import (
+	"io"
+
+	"github.com/katydid/parser-go-json/json/scan"
+)
+
+// Indent appends the JSON text in src to dst, with each element of an array or object on a new line,
+// that starts with the prefix followed by one copy of the indent for each level of nesting.
+// The first line does not start with the prefix and empty arrays and objects are written as [] and {}.
+// Leading and trailing whitespace is removed.
+func Indent(dst []byte, src []byte, prefix, indent string) ([]byte, error) {
+	return NewFormatter().Indent(dst, src, prefix, indent)
+}
+
+// Compact appends the JSON text in src to dst, without any whitespace between the tokens.
+func Compact(dst []byte, src []byte) ([]byte, error) {
+	return NewFormatter().Compact(dst, src)
+}
+
+// IndentReader reads JSON text from r and writes the indented JSON text to w, see Indent.
+func IndentReader(w io.Writer, r io.Reader, prefix, indent string) error {
+	return NewFormatter().IndentReader(w, r, prefix, indent)
+}
+
+// CompactReader reads JSON text from r and writes it to w, without any whitespace between the tokens.
+func CompactReader(w io.Writer, r io.Reader) error {
+	return NewFormatter().CompactReader(w, r)
+}
+
+// Formatter reformats JSON text and reuses its memory, so that it does not allocate after warm-up.
+// Syntax errors are returned as a *scan.SyntaxError, with the position of the token in the input,
+// and are the same errors that are returned by the parser in the json/parse package.
+type Formatter struct {
+	scanner scan.Scanner
+	// stack contains the kind of the token that closes each array or object that is open.
+	stack []scan.Kind
+	// buf is the output buffer that is reused by IndentReader and CompactReader.
+	buf []byte
+}
+
+// flushSize is the size of the output that is buffered, before it is written by IndentReader and CompactReader.
+const flushSize = 4096
+
+// NewFormatter returns a new Formatter.
+func NewFormatter() *Formatter {
+	return &Formatter{
+		scanner: scan.NewScanner(nil),
+		stack:   make([]scan.Kind, 0, 10),
+	}
+}
+
+// Indent appends the indented JSON text in src to dst, see Indent.
+func (f *Formatter) Indent(dst []byte, src []byte, prefix, indent string) ([]byte, error) {
+	f.scanner.Init(src)
+	return f.format(dst, nil, true, prefix, indent)
+}
+
+// Compact appends the compacted JSON text in src to dst, see Compact.
+func (f *Formatter) Compact(dst []byte, src []byte) ([]byte, error) {
+	f.scanner.Init(src)
+	return f.format(dst, nil, false, "", "")
+}
+
+// IndentReader reads JSON text from r and writes the indented JSON text to w, see Indent.
+// The input is read into a sliding window and the output is written in chunks,
+// so that neither the input nor the output needs to fit into memory.
+// If an error is returned, the output before the error might already have been written to w.
+func (f *Formatter) IndentReader(w io.Writer, r io.Reader, prefix, indent string) error {
+	f.scanner.InitReader(r)
+	buf, err := f.format(f.buf[:0], w, true, prefix, indent)
+	f.buf = buf[:0]
+	return err
+}
+
+// CompactReader reads JSON text from r and writes the compacted JSON text to w, see IndentReader.
+func (f *Formatter) CompactReader(w io.Writer, r io.Reader) error {
+	f.scanner.InitReader(r)
+	buf, err := f.format(f.buf[:0], w, false, "", "")
+	f.buf = buf[:0]
+	return err
+}
+
+type state byte
+
+const (
+	// valueState expects a value at the top level or after a colon.
+	valueState = state('v')
+	// arrayOpenState expects the first element of an array or the end of the array.
+	arrayOpenState = state('[')
+	// arrayElementState expects an element of an array after a comma.
+	arrayElementState = state('a')
+	// objectOpenState expects the first key of an object or the end of the object.
+	objectOpenState = state('{')
+	// objectKeyState expects a key of an object after a comma.
+	objectKeyState = state('k')
+	// colonState expects the colon after a key.
+	colonState = state(':')
+	// commaOrCloseState expects a comma or the end of the array or object after a value.
+	commaOrCloseState = state(',')
+	// endState expects the end of the input.
+	endState = state('e')
+)
+
+// format appends the reformatted input of the scanner to dst.
+// If w is not nil, the output is written to w, whenever it grows larger than flushSize, and at the end.
+func (f *Formatter) format(dst []byte, w io.Writer, pretty bool, prefix, indent string) ([]byte, error) {
+	f.stack = f.stack[:0]
+	s := valueState
+	for {
+		if w != nil && len(dst) >= flushSize {
+			if _, err := w.Write(dst); err != nil {
+				return dst, err
+			}
+			dst = dst[:0]
+		}
+		kind, token, err := f.next(s)
+		if err != nil {
+			if err != io.EOF {
+				return dst, err
+			}
+			if w != nil {
+				if _, err := w.Write(dst); err != nil {
+					return dst, err
+				}
+				dst = dst[:0]
+			}
+			return dst, nil
+		}
+		switch s {
+		case valueState, arrayOpenState, arrayElementState:
+			if s == arrayOpenState && kind == scan.ArrayCloseKind {
+				f.stack = f.stack[:len(f.stack)-1]
+				dst = append(dst, token...)
+				s = f.afterValue()
+				continue
+			}
+			if !isValue(kind) {
+				if s == arrayOpenState {
+					return dst, f.scanner.SyntaxError(scan.ErrExpectedValue, kind, scan.ExpectedValueOrCloseBracket)
+				}
+				return dst, f.scanner.SyntaxError(scan.ErrExpectedValue, kind, scan.ExpectedValue)
+			}
+			if pretty && s != valueState {
+				dst = f.newline(dst, prefix, indent)
+			}
+			dst = append(dst, token...)
+			switch kind {
+			case scan.ArrayOpenKind:
+				f.stack = append(f.stack, scan.ArrayCloseKind)
+				s = arrayOpenState
+			case scan.ObjectOpenKind:
+				f.stack = append(f.stack, scan.ObjectCloseKind)
+				s = objectOpenState
+			default:
+				s = f.afterValue()
+			}
+		case objectOpenState, objectKeyState:
+			if s == objectOpenState && kind == scan.ObjectCloseKind {
+				f.stack = f.stack[:len(f.stack)-1]
+				dst = append(dst, token...)
+				s = f.afterValue()
+				continue
+			}
+			if kind != scan.StringKind {
+				if s == objectOpenState {
+					return dst, f.scanner.SyntaxError(scan.ErrExpectedStringOrCloseCurly, kind, scan.ExpectedStringOrCloseCurly)
+				}
+				return dst, f.scanner.SyntaxError(scan.ErrExpectedString, kind, scan.ExpectedString)
+			}
+			if pretty {
+				dst = f.newline(dst, prefix, indent)
+			}
+			dst = append(dst, token...)
+			s = colonState
+		case colonState:
+			if kind != scan.ColonKind {
+				return dst, f.scanner.SyntaxError(scan.ErrExpectedColon, kind, scan.ExpectedColon)
+			}
+			dst = append(dst, token...)
+			if pretty {
+				dst = append(dst, ' ')
+			}
+			s = valueState
+		case commaOrCloseState:
+			close := f.stack[len(f.stack)-1]
+			switch kind {
+			case scan.CommaKind:
+				dst = append(dst, token...)
+				if close == scan.ArrayCloseKind {
+					s = arrayElementState
+				} else {
+					s = objectKeyState
+				}
+			case close:
+				f.stack = f.stack[:len(f.stack)-1]
+				if pretty {
+					dst = f.newline(dst, prefix, indent)
+				}
+				dst = append(dst, token...)
+				s = f.afterValue()
+			default:
+				if close == scan.ArrayCloseKind {
+					return dst, f.scanner.SyntaxError(scan.ErrExpectedCommaOrCloseBracket, kind, scan.ExpectedCommaOrCloseBracket)
+				}
+				return dst, f.scanner.SyntaxError(scan.ErrExpectedCommaOrCloseCurly, kind, scan.ExpectedCommaOrCloseCurly)
+			}
+		case endState:
+			return dst, f.scanner.SyntaxError(scan.ErrExpectedEndOfInput, kind, nil)
+		}
+	}
+}
+
+// next scans the next token.
+// It returns io.EOF only if the end of the input is reached where it is expected.
+func (f *Formatter) next(s state) (scan.Kind, []byte, error) {
+	kind, _, err := f.scanner.NextStart()
+	if err != nil {
+		if err != io.EOF {
+			return kind, nil, err
+		}
+		if s == endState {
+			return kind, nil, io.EOF
+		}
+		return kind, nil, f.scanner.SyntaxError(scan.ErrUnexpectedEndOfInput, scan.UnknownKind, nil)
+	}
+	if kind == scan.UnknownKind {
+		return kind, nil, nil
+	}
+	token, err := f.scanner.ScanToEnd(kind)
+	if err == io.ErrShortBuffer {
+		return kind, nil, f.scanner.SyntaxError(scan.ErrUnexpectedEndOfInput, kind, nil)
+	}
+	return kind, token, err
+}
+
+// afterValue returns the state after a complete value.
+func (f *Formatter) afterValue() state {
+	if len(f.stack) == 0 {
+		return endState
+	}
+	return commaOrCloseState
+}
+
+// newline appends a new line, followed by the prefix and the indent repeated for each open array or object.
+func (f *Formatter) newline(dst []byte, prefix, indent string) []byte {
+	dst = append(dst, '\n')
+	dst = append(dst, prefix...)
+	for range f.stack {
+		dst = append(dst, indent...)
+	}
+	return dst
+}
+
+func isValue(kind scan.Kind) bool {
+	switch kind {
+	case scan.NullKind, scan.FalseKind, scan.TrueKind, scan.NumberKind, scan.StringKind, scan.ArrayOpenKind, scan.ObjectOpenKind:
+		return true
+	}
+	return false
+}
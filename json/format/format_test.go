@@ -0,0 +1,226 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package format
+
+import (
+	"bytes"
+	gojson "encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	jsonparse "github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/scan"
+	"github.com/katydid/parser-go/parse/debug"
+)
+
+var inputs = []string{
+	`null`,
+	` true `,
+	`"aA\n"`,
+	`1.0E+2`,
+	`-0`,
+	`[]`,
+	`{}`,
+	`[ ]`,
+	`{ }`,
+	`[1, 2 ,3]`,
+	`{"a" : 1 , "b" : [ true , false , null ] }`,
+	"{\n\t\"a\": {\"b\": [[], {}, [{}]]},\n\t\"c\": \"\\/\"\n}\n",
+	`[[[[1e400]]], {"":{"":{"":""}}}]`,
+}
+
+func TestIndent(t *testing.T) {
+	for _, input := range inputs {
+		got, err := Indent(nil, []byte(input), "> ", "\t")
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		want := bytes.NewBuffer(nil)
+		if err := gojson.Indent(want, bytes.TrimSpace([]byte(input)), "> ", "\t"); err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want.String() {
+			t.Fatalf("%s: want\n%s\ngot\n%s", input, want.String(), got)
+		}
+	}
+}
+
+func TestCompact(t *testing.T) {
+	for _, input := range inputs {
+		got, err := Compact(nil, []byte(input))
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		want := bytes.NewBuffer(nil)
+		if err := gojson.Compact(want, []byte(input)); err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want.String() {
+			t.Fatalf("%s: want %s, got %s", input, want.String(), got)
+		}
+	}
+}
+
+func TestIndentAppends(t *testing.T) {
+	got, err := Indent([]byte("x = "), []byte(`{"a":[1]}`), "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "x = {\n  \"a\": [\n    1\n  ]\n}"
+	if string(got) != want {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		err    error
+		offset int
+	}{
+		{``, scan.ErrUnexpectedEndOfInput, 0},
+		{`[1,2`, scan.ErrUnexpectedEndOfInput, 3},
+		{`{"a":1,}`, scan.ErrExpectedString, 7},
+		{`{"a" 1}`, scan.ErrExpectedColon, 5},
+		{`{1:2}`, scan.ErrExpectedStringOrCloseCurly, 1},
+		{`[1 2]`, scan.ErrExpectedCommaOrCloseBracket, 3},
+		{`{"a":1]`, scan.ErrExpectedCommaOrCloseCurly, 6},
+		{`[,]`, scan.ErrExpectedValue, 1},
+		{`[1,]`, scan.ErrExpectedValue, 3},
+		{`{} {}`, scan.ErrExpectedEndOfInput, 3},
+		{"\n[x]", scan.ErrExpectedValue, 2},
+	}
+	for _, test := range tests {
+		for _, format := range []func([]byte) ([]byte, error){
+			func(src []byte) ([]byte, error) { return Compact(nil, src) },
+			func(src []byte) ([]byte, error) { return Indent(nil, src, "", "\t") },
+		} {
+			_, err := format([]byte(test.input))
+			if !errors.Is(err, test.err) {
+				t.Fatalf("%s: want %v, got %v", test.input, test.err, err)
+			}
+			var syntaxErr *scan.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("%s: want *scan.SyntaxError, got %T", test.input, err)
+			}
+			if syntaxErr.Offset != test.offset {
+				t.Fatalf("%s: want offset %d, got %d", test.input, test.offset, syntaxErr.Offset)
+			}
+		}
+	}
+}
+
+func TestReader(t *testing.T) {
+	// The output is larger than flushSize, so that it is written in more than one chunk.
+	large := "[" + strings.Repeat(`{"a" : [1, "b"]}, `, flushSize/8) + "null]"
+	for _, input := range append(inputs, large) {
+		want, err := Indent(nil, []byte(input), "> ", "\t")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := bytes.NewBuffer(nil)
+		if err := IndentReader(got, iotest.OneByteReader(strings.NewReader(input)), "> ", "\t"); err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if got.String() != string(want) {
+			t.Fatalf("%s: want\n%s\ngot\n%s", input, want, got.String())
+		}
+		want, err = Compact(nil, []byte(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got.Reset()
+		if err := CompactReader(got, iotest.OneByteReader(strings.NewReader(input))); err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if got.String() != string(want) {
+			t.Fatalf("%s: want %s, got %s", input, want, got.String())
+		}
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	_, want := Compact(nil, []byte(`{"a":[1 2]}`))
+	got := CompactReader(io.Discard, strings.NewReader(`{"a":[1 2]}`))
+	if got == nil || got.Error() != want.Error() {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
+
+// TestSameErrorsAsParser checks that the formatter and the parser share the same grammar.
+func TestSameErrorsAsParser(t *testing.T) {
+	invalid := []string{`[}`, `[,`, `[1,]`, `{,`, `{"a" 1}`, `{"a":}`, `{"a":1,}`, `{"a":1 2`, `[1 2]`, `1 2`, `[1`, `{1:2}`, `]`, `[1}`, `{"a":1]`, `tru`}
+	for _, input := range invalid {
+		_, want := debug.Parse(jsonparse.NewParser(jsonparse.WithBuffer([]byte(input))))
+		_, got := Compact(nil, []byte(input))
+		var wantErr, gotErr *scan.SyntaxError
+		if !errors.As(want, &wantErr) || !errors.As(got, &gotErr) {
+			t.Fatalf("%s: want syntax errors, but got %v and %v", input, want, got)
+		}
+		if wantErr.Err != gotErr.Err || wantErr.Position != gotErr.Position || wantErr.Found != gotErr.Found || !slices.Equal(wantErr.Expected, gotErr.Expected) {
+			t.Fatalf("%s: want %v, got %v", input, wantErr, gotErr)
+		}
+	}
+}
+
+func TestFormatterNoAllocs(t *testing.T) {
+	f := NewFormatter()
+	src := []byte(`{"a" : [1.50, "b\n", {"c" : [ ] }], "d" : {"e" : null}}`)
+	dst, err := f.Indent(nil, src, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		dst, err = f.Indent(dst[:0], src, "", "  ")
+		if err != nil {
+			return
+		}
+		dst, err = f.Compact(dst[:0], src)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocs != 0 {
+		t.Fatalf("want 0 allocs, got %v", allocs)
+	}
+}
+
+func TestReaderNoAllocs(t *testing.T) {
+	f := NewFormatter()
+	src := []byte(`{"a" : [1.50, "b\n", {"c" : [ ] }], "d" : {"e" : null}}`)
+	r := bytes.NewReader(src)
+	if err := f.IndentReader(io.Discard, r, "", "  "); err != nil {
+		t.Fatal(err)
+	}
+	var err error
+	allocs := testing.AllocsPerRun(100, func() {
+		r.Reset(src)
+		if err = f.IndentReader(io.Discard, r, "", "  "); err != nil {
+			return
+		}
+		r.Reset(src)
+		err = f.CompactReader(io.Discard, r)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allocs != 0 {
+		t.Fatalf("want 0 allocs, got %v", allocs)
+	}
+}
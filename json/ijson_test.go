@@ -0,0 +1,91 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package json
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go/parse/debug"
+)
+
+func expectIJSONError(t *testing.T, input string, want error) {
+	t.Helper()
+	p := NewIJSONParser()
+	p.Init([]byte(input))
+	if err := debug.Walk(p); !errors.Is(err, want) {
+		t.Fatalf("%s: expected %v, but got %v", input, want, err)
+	}
+	// The error is also returned when the value is skipped.
+	p.Init([]byte(input))
+	if _, err := p.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Skip(); !errors.Is(err, want) {
+		t.Fatalf("%s: expected %v when skipping, but got %v", input, want, err)
+	}
+}
+
+func TestIJSONValid(t *testing.T) {
+	inputs := []string{
+		`{"a":[1,-9007199254740991,9007199254740991,1.5,1e300,9007199254740993.0],"b":"\u00e9\ud83d\ude00","c":{"a":null}}`,
+		"[\"\u00e9\U0001F600\"]",
+	}
+	p := NewIJSONParser()
+	for _, input := range inputs {
+		p.Init([]byte(input))
+		if err := debug.Walk(p); err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+	}
+}
+
+func TestIJSONUTF8(t *testing.T) {
+	expectIJSONError(t, "[\"\xff\"]", parse.ErrInvalidUTF8)
+	expectIJSONError(t, "{\"\xc3\x28\":1}", parse.ErrInvalidUTF8)
+}
+
+func TestIJSONLoneSurrogate(t *testing.T) {
+	expectIJSONError(t, `["\ud800"]`, parse.ErrLoneSurrogate)
+	expectIJSONError(t, `["\ude00\ud83d"]`, parse.ErrLoneSurrogate)
+}
+
+func TestIJSONDuplicateNames(t *testing.T) {
+	expectIJSONError(t, `{"a":1,"b":2,"a":3}`, parse.ErrDuplicateKey)
+	expectIJSONError(t, `[{"a":{"b":1,"\u0062":2}}]`, parse.ErrDuplicateKey)
+}
+
+func TestIJSONIntegers(t *testing.T) {
+	expectIJSONError(t, `[9007199254740992]`, parse.ErrUnsafeInteger)
+	expectIJSONError(t, `[-9007199254740992]`, parse.ErrUnsafeInteger)
+	expectIJSONError(t, `{"id":18446744073709551615}`, parse.ErrUnsafeInteger)
+}
+
+func TestWithoutIJSON(t *testing.T) {
+	inputs := []string{
+		"[\"\xff\"]",
+		`["\ud800"]`,
+		`{"a":1,"a":2}`,
+		`[9007199254740992]`,
+	}
+	p := NewParser()
+	for _, input := range inputs {
+		p.Init([]byte(input))
+		if err := debug.Walk(p); err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package unquote
+
+import (
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// UnquoteJSON5 is the same as Unquote, except that it unquotes a JSON5 string,
+// which can be quoted with single or double quotes and allows more escapes:
+// '\v', '\0', '\xXX', line continuations and any other character that escapes itself.
+func UnquoteJSON5(alloc func(size int) []byte, s []byte) (t []byte, offset int, ok bool) {
+	if len(s) < 2 || (s[0] != '"' && s[0] != '\'') {
+		return nil, 0, false
+	}
+	quote := s[0]
+
+	// If there are no escapes, then no unquoting is needed,
+	// so return a slice of the original bytes.
+	r := 1
+	for r < len(s) && s[r] != quote && s[r] != '\\' && s[r] < utf8.RuneSelf && s[r] != '\n' && s[r] != '\r' {
+		r++
+	}
+	if r >= len(s) {
+		return nil, 0, false
+	}
+	if s[r] == quote {
+		return s[1:r], r + 1, true
+	}
+
+	b := alloc(len(s) * utf8.UTFMax)
+	w := copy(b, s[1:r])
+	for r < len(s) {
+		c := s[r]
+		switch {
+		case c == quote:
+			return b[0:w], r + 1, true
+		case c == '\n' || c == '\r':
+			return nil, 0, false
+		case c == '\\':
+			r++
+			if r >= len(s) {
+				return nil, 0, false
+			}
+			n, size := unescape5(b[w:], s[r:])
+			if size == 0 {
+				return nil, 0, false
+			}
+			r += size
+			w += n
+		case c < utf8.RuneSelf:
+			b[w] = c
+			r++
+			w++
+		default:
+			// Coerce to well-formed UTF-8.
+			rr, size := utf8.DecodeRune(s[r:])
+			r += size
+			w += utf8.EncodeRune(b[w:], rr)
+		}
+	}
+	return nil, 0, false
+}
+
+// unescape5 writes the character of the JSON5 escape that follows a backslash to b.
+// It returns the number of bytes written and the size of the escape, which is zero if the escape is invalid.
+func unescape5(b []byte, s []byte) (int, int) {
+	switch c := s[0]; c {
+	case 'b':
+		b[0] = '\b'
+	case 'f':
+		b[0] = '\f'
+	case 'n':
+		b[0] = '\n'
+	case 'r':
+		b[0] = '\r'
+	case 't':
+		b[0] = '\t'
+	case 'v':
+		b[0] = '\v'
+	case '0':
+		if len(s) > 1 && '0' <= s[1] && s[1] <= '9' {
+			return 0, 0
+		}
+		b[0] = 0
+	case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return 0, 0
+	case '\n':
+		// line continuation
+		return 0, 1
+	case '\r':
+		if len(s) > 1 && s[1] == '\n' {
+			return 0, 2
+		}
+		return 0, 1
+	case 'x':
+		if len(s) < 3 || u4table[s[1]] < 0 || u4table[s[2]] < 0 {
+			return 0, 0
+		}
+		return utf8.EncodeRune(b, u4table[s[1]]*16+u4table[s[2]]), 3
+	case 'u':
+		rr := hex4(s)
+		if rr < 0 {
+			return 0, 0
+		}
+		if utf16.IsSurrogate(rr) {
+			if len(s) >= 11 && s[5] == '\\' {
+				if dec := utf16.DecodeRune(rr, hex4(s[6:])); dec != unicode.ReplacementChar {
+					// A valid pair; consume.
+					return utf8.EncodeRune(b, dec), 11
+				}
+			}
+			// Invalid surrogate; fall back to replacement rune.
+			rr = unicode.ReplacementChar
+		}
+		return utf8.EncodeRune(b, rr), 5
+	default:
+		if c < utf8.RuneSelf {
+			b[0] = c
+			return 1, 1
+		}
+		rr, size := utf8.DecodeRune(s)
+		if rr == '\u2028' || rr == '\u2029' {
+			// line continuation
+			return 0, size
+		}
+		return utf8.EncodeRune(b, rr), size
+	}
+	return 1, 1
+}
+
+// hex4 decodes uXXXX from the beginning of s, returning the hex value,
+// or it returns -1.
+func hex4(s []byte) rune {
+	if len(s) < 5 || s[0] != 'u' {
+		return -1
+	}
+	var r rune
+	for _, c := range s[1:5] {
+		r1 := u4table[c]
+		if r1 == -1 {
+			return -1
+		}
+		r = r*16 + r1
+	}
+	return r
+}
+
+// UnquoteIdentifier returns the unescaped JSON5 identifier.
+// If the identifier does not contain any escapes, then the identifier itself is returned.
+func UnquoteIdentifier(alloc func(size int) []byte, s []byte) ([]byte, bool) {
+	i := 0
+	for i < len(s) && s[i] != '\\' {
+		i++
+	}
+	if i == len(s) {
+		return s, true
+	}
+	b := alloc(len(s))
+	w := copy(b, s[:i])
+	for i < len(s) {
+		if s[i] != '\\' {
+			b[w] = s[i]
+			w++
+			i++
+			continue
+		}
+		rr := hex4(s[i+1:])
+		if rr < 0 || utf16.IsSurrogate(rr) {
+			return nil, false
+		}
+		// An escaped character is at most 3 bytes, which is less than the 6 bytes of its escape.
+		w += utf8.EncodeRune(b[w:], rr)
+		i += 6
+	}
+	return b[:w], true
+}
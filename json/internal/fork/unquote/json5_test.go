@@ -0,0 +1,81 @@
+//  Copyright 2026 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package unquote
+
+import (
+	"bytes"
+	"testing"
+)
+
+var unquote5tests = []struct {
+	in  string
+	out string
+}{
+	{`''`, ""},
+	{`'abc'`, "abc"},
+	{`"abc"`, "abc"},
+	{`'a"b'`, "a\"b"},
+	{`"a'b"`, "a'b"},
+	{`'a\'b'`, "a'b"},
+	{`'\x41\u0042'`, "AB"},
+	{`'\xe9'`, "\u00e9"},
+	{`'\ud83d\ude00'`, "\U0001F600"},
+	{`'\b\f\n\r\t\v\0'`, "\b\f\n\r\t\v\x00"},
+	{`'\q\/\\'`, "q/\\"},
+	{"'a\\\nb'", "ab"},
+	{"'a\\\r\nb'", "ab"},
+	{"'a\\\rb'", "ab"},
+	{"'a\\\u2028b'", "ab"},
+	{"'\u00e9\\\u00e9'", "\u00e9\u00e9"},
+	{"'a\tb'", "a\tb"},
+}
+
+func TestUnquoteJSON5(t *testing.T) {
+	alloc := func(size int) []byte { return make([]byte, size) }
+	for _, test := range unquote5tests {
+		got, offset, ok := UnquoteJSON5(alloc, []byte(test.in))
+		if !bytes.Equal(got, []byte(test.out)) || !ok {
+			t.Errorf("UnquoteJSON5(%q) = (%q, %d, %v), want (%q, %v)", test.in, got, offset, ok, test.out, true)
+		}
+		if offset != len(test.in) {
+			t.Errorf("UnquoteJSON5(%q) offset = %d, want %d", test.in, offset, len(test.in))
+		}
+	}
+	invalid := []string{`'abc"`, "'a\nb'", `'\1'`, `'\01'`, `'\x4'`, `'\u004'`, `abc`}
+	for _, in := range invalid {
+		if _, _, ok := UnquoteJSON5(alloc, []byte(in)); ok {
+			t.Errorf("UnquoteJSON5(%q) should fail", in)
+		}
+	}
+}
+
+func TestUnquoteIdentifier(t *testing.T) {
+	alloc := func(size int) []byte { return make([]byte, size) }
+	tests := map[string]string{
+		"abc":             "abc",
+		"\\u0061bc":       "abc",
+		"a\\u00e9\\u03c0": "a\u00e9\u03c0",
+		"\u00e9":          "\u00e9",
+	}
+	for in, want := range tests {
+		got, ok := UnquoteIdentifier(alloc, []byte(in))
+		if !ok || string(got) != want {
+			t.Errorf("UnquoteIdentifier(%q) = (%q, %v), want %q", in, got, ok, want)
+		}
+	}
+	if _, ok := UnquoteIdentifier(alloc, []byte("a\\ud800")); ok {
+		t.Errorf("an escaped surrogate is not a valid identifier character")
+	}
+}
@@ -16,7 +16,11 @@
 package json
 
 import (
+	"io"
+
+	"github.com/katydid/parser-go-json/json/jsonschema"
 	"github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/scan"
 	"github.com/katydid/parser-go-json/json/tag"
 	goparse "github.com/katydid/parser-go/parse"
 	"github.com/katydid/parser-go/pool"
@@ -27,11 +31,52 @@ type Parser interface {
 	Reset()
 	// Init restarts the parser with a new byte buffer, without allocating a new parser.
 	Init([]byte)
+	// InitReader restarts the parser with a reader, without allocating a new parser.
+	// Byte slices returned by Token are only valid until the next call to Next.
+	InitReader(io.Reader)
+	// Feed appends a chunk of input to the parser.
+	// Until Close is called, Next returns parse.ErrNeedMoreInput, when a token is cut off at the end of the input.
+	// A new parser, that has not been initialized with Init or InitReader, waits for the first chunk to be fed.
+	// Calling Next again, after feeding more input, resumes exactly where it stopped.
+	// Byte slices returned by Token are only valid until the next call to Next.
+	Feed([]byte)
+	// Close signals that no more input will be fed to the parser.
+	Close()
+	// NextDocument moves the parser to the start of the next document, when the input contains multiple documents, see NewLinesParser.
+	// Whatever is left of the current document is skipped.
+	// It returns io.EOF if there are no more documents.
+	NextDocument() error
+	// RawValue skips over the current value and returns the exact bytes of the input that it spans.
+	// The current value is the value that Next just returned a ValueHint or EnterHint for,
+	// or the value of the field or array index that Next just returned a FieldHint for.
+	// The returned bytes are only valid until the next call to Next, Skip or RawValue.
+	RawValue() ([]byte, error)
+	// Offset returns the offset in bytes of the current token from the start of the input.
+	Offset() int
+	// Position returns the offset, line and column of the current token.
+	// The line and column are only calculated when Position is called.
+	Position() scan.Position
+	// Comment returns the comments before the current token, if the parser allows comments, see NewJSONCParser.
+	// The returned bytes are only valid until the next call to Next or Skip.
+	Comment() []byte
+	// Uint returns the current value as an unsigned integer, if it is an integer between 0 and math.MaxUint64.
+	// Integers larger than math.MaxInt64 are returned by Token as a parse.DecimalKind,
+	// but are parsed into a uint64 without a round trip through their text.
+	// It returns parse.ErrNotUint for any other value.
+	Uint() (uint64, error)
+	// JSONSchemaType distinguishes between objects and arrays, after Next returned an EnterHint.
+	JSONSchemaType() jsonschema.JSONSchemaType
 }
 
 type parserWithReset interface {
 	goparse.Parser
 	Reset()
+	RawValue() ([]byte, error)
+	Offset() int
+	Position() scan.Position
+	Comment() []byte
+	Uint() (uint64, error)
+	JSONSchemaType() jsonschema.JSONSchemaType
 }
 
 type jsonParser struct {
@@ -40,12 +85,17 @@ type jsonParser struct {
 	pool       pool.Pool
 }
 
+// DefaultMaxDepth is the maximum nesting depth of arrays and objects that the parsers in this package accept.
+// Deeper input results in parse.ErrMaxDepthExceeded.
+// A lower maximum can be passed to NewLinesParser, NewSequenceParser and NewConcatenatedParser using parse.WithMaxDepth.
+const DefaultMaxDepth = 10000
+
 // NewParser returns a new JSON parser with indexes.
 // Use this parser with other Katydid tools, such as the validator.
 func NewParser() Parser {
 	p := pool.New()
-	underlyingParser := parse.NewParser(parse.WithAllocator(p.Alloc))
-	tagged := tag.NewTagger(underlyingParser, tag.WithAllocator(p.Alloc), tag.WithIndexes())
+	underlyingParser := parse.NewParser(parse.WithAllocator(p.Alloc), parse.WithMaxDepth(DefaultMaxDepth))
+	tagged := tag.NewTagger(underlyingParser, tag.WithAllocator(p.Alloc), tag.WithMaxDepth(DefaultMaxDepth), tag.WithIndexes())
 	return &jsonParser{parserWithReset: tagged, underlying: underlyingParser, pool: p}
 }
 
@@ -55,8 +105,64 @@ func NewParser() Parser {
 // The kind returned from the Token method for "object" and "array" will be parse.TagKind.
 func NewJSONSchemaParser() Parser {
 	p := pool.New()
-	underlyingParser := parse.NewParser(parse.WithAllocator(p.Alloc))
-	tagged := tag.NewTagger(underlyingParser, tag.WithAllocator(p.Alloc), tag.WithIndexes(), tag.WithTags())
+	underlyingParser := parse.NewParser(parse.WithAllocator(p.Alloc), parse.WithMaxDepth(DefaultMaxDepth))
+	tagged := tag.NewTagger(underlyingParser, tag.WithAllocator(p.Alloc), tag.WithMaxDepth(DefaultMaxDepth), tag.WithIndexes(), tag.WithTags())
+	return &jsonParser{parserWithReset: tagged, underlying: underlyingParser, pool: p}
+}
+
+// NewIJSONParser returns a new JSON parser with indexes, that only accepts I-JSON messages (RFC 7493), see parse.WithIJSON.
+// Strings must be valid UTF-8 without unpaired surrogates,
+// objects may not contain duplicate keys and integers must be within the range [-(2^53)+1, (2^53)-1].
+func NewIJSONParser() Parser {
+	p := pool.New()
+	underlyingParser := parse.NewParser(parse.WithAllocator(p.Alloc), parse.WithMaxDepth(DefaultMaxDepth), parse.WithIJSON())
+	tagged := tag.NewTagger(underlyingParser, tag.WithAllocator(p.Alloc), tag.WithMaxDepth(DefaultMaxDepth), tag.WithIndexes())
+	return &jsonParser{parserWithReset: tagged, underlying: underlyingParser, pool: p}
+}
+
+// NewJSONCParser returns a new JSON parser with indexes, that allows comments and trailing commas, see parse.WithJSONC.
+// The comments before the current token are returned by Comment.
+func NewJSONCParser() Parser {
+	p := pool.New()
+	underlyingParser := parse.NewParser(parse.WithAllocator(p.Alloc), parse.WithMaxDepth(DefaultMaxDepth), parse.WithJSONC())
+	tagged := tag.NewTagger(underlyingParser, tag.WithAllocator(p.Alloc), tag.WithMaxDepth(DefaultMaxDepth), tag.WithIndexes())
+	return &jsonParser{parserWithReset: tagged, underlying: underlyingParser, pool: p}
+}
+
+// NewJSON5Parser returns a new JSON parser with indexes, that parses JSON5 (https://spec.json5.org), see parse.WithJSON5.
+// JSON5 allows comments, single quoted strings, identifiers as object keys, trailing commas and more number formats.
+func NewJSON5Parser() Parser {
+	p := pool.New()
+	underlyingParser := parse.NewParser(parse.WithAllocator(p.Alloc), parse.WithMaxDepth(DefaultMaxDepth), parse.WithJSON5())
+	tagged := tag.NewTagger(underlyingParser, tag.WithAllocator(p.Alloc), tag.WithMaxDepth(DefaultMaxDepth), tag.WithIndexes())
+	return &jsonParser{parserWithReset: tagged, underlying: underlyingParser, pool: p}
+}
+
+// NewLinesParser returns a new JSON parser with indexes for newline delimited JSON (JSON Lines or NDJSON).
+// Each line is parsed as a separate document, use NextDocument to move to the next line.
+// Options, such as parse.WithSkipBlankLines and parse.WithContinueAfterError, are passed to the underlying parser.
+func NewLinesParser(opts ...parse.Option) Parser {
+	return newDocumentsParser(parse.WithLines(), opts)
+}
+
+// NewSequenceParser returns a new JSON parser with indexes for JSON text sequences (RFC 7464), where each value is preceded by a record separator.
+// Each record is parsed as a separate document, use NextDocument to move to the next record.
+// Options, such as parse.WithContinueAfterError, are passed to the underlying parser.
+func NewSequenceParser(opts ...parse.Option) Parser {
+	return newDocumentsParser(parse.WithSequence(), opts)
+}
+
+// NewConcatenatedParser returns a new JSON parser with indexes for concatenated JSON values, for example `{"a":1}{"b":2}`.
+// Each value is parsed as a separate document, use NextDocument to move to the next value.
+func NewConcatenatedParser(opts ...parse.Option) Parser {
+	return newDocumentsParser(parse.WithConcatenated(), opts)
+}
+
+func newDocumentsParser(documents parse.Option, opts []parse.Option) Parser {
+	p := pool.New()
+	opts = append([]parse.Option{documents, parse.WithAllocator(p.Alloc), parse.WithMaxDepth(DefaultMaxDepth)}, opts...)
+	underlyingParser := parse.NewParser(opts...)
+	tagged := tag.NewTagger(underlyingParser, tag.WithAllocator(p.Alloc), tag.WithMaxDepth(DefaultMaxDepth), tag.WithIndexes())
 	return &jsonParser{parserWithReset: tagged, underlying: underlyingParser, pool: p}
 }
 
@@ -67,3 +173,28 @@ func (p *jsonParser) Init(buf []byte) {
 	p.pool.FreeAll()
 	return
 }
+
+func (p *jsonParser) InitReader(r io.Reader) {
+	p.parserWithReset.Reset()
+	p.underlying.InitReader(r)
+	p.pool.FreeAll()
+}
+
+func (p *jsonParser) Feed(buf []byte) {
+	p.underlying.Feed(buf)
+}
+
+func (p *jsonParser) Close() {
+	p.underlying.Close()
+}
+
+func (p *jsonParser) NextDocument() error {
+	if err := p.underlying.NextDocument(); err != nil {
+		return err
+	}
+	// Reset the tagger, which also resets the state of the underlying parser, but not its position in the input.
+	p.parserWithReset.Reset()
+	// Tokens of the previous document are no longer needed.
+	p.pool.FreeAll()
+	return nil
+}
@@ -0,0 +1,54 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package json
+
+import (
+	"testing"
+
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+	"github.com/katydid/parser-go/parse/debug"
+)
+
+func TestJSON5Parser(t *testing.T) {
+	p := NewJSON5Parser()
+	p.Init([]byte(`{
+	// A list of names
+	names: ['a', "b",],
+}`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "names")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Int(t, p, 0)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "b")
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestJSON5ParserInvalidJSON(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(`{a: 1}`))
+	if err := debug.Walk(p); err == nil {
+		t.Fatal("expected identifiers to be invalid JSON")
+	}
+}
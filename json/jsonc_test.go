@@ -0,0 +1,55 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package json
+
+import (
+	"testing"
+
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func TestJSONCParser(t *testing.T) {
+	p := NewJSONCParser()
+	p.Init([]byte(`{
+	// The files to compile.
+	"files": [
+		"a.ts", // The first file.
+		"b.ts",
+	],
+}`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	if got := string(p.Comment()); got != "// The files to compile." {
+		t.Fatalf("unexpected comment %q", got)
+	}
+	expect.String(t, p, "files")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Int(t, p, 0)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "a.ts")
+	// The comment of the index is the comment of the element that it indexes.
+	expect.Hint(t, p, parse.FieldHint)
+	if got := string(p.Comment()); got != "// The first file." {
+		t.Fatalf("unexpected comment %q", got)
+	}
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "b.ts")
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
@@ -0,0 +1,90 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go/parse/debug"
+)
+
+func TestLinesSameAsInit(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	for i := range values {
+		// JSON strings cannot contain a raw newline, so only whitespace is replaced.
+		values[i] = bytes.ReplaceAll(values[i], []byte("\n"), []byte(" "))
+	}
+	want := NewParser()
+	got := NewLinesParser()
+	got.Init(bytes.Join(values, []byte("\n")))
+	for _, value := range values {
+		if err := got.NextDocument(); err != nil {
+			t.Fatalf("expected next document, but got %v", err)
+		}
+		want.Init(value)
+		expectSameWalk(t, want, got)
+	}
+	if err := got.NextDocument(); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
+
+func TestLinesSkipBlankLines(t *testing.T) {
+	p := NewLinesParser(parse.WithSkipBlankLines())
+	p.Init([]byte("\n{\"a\":[1,2]}\n\n  \n[3]\n"))
+	want := NewParser()
+	for _, value := range []string{`{"a":[1,2]}`, `[3]`} {
+		if err := p.NextDocument(); err != nil {
+			t.Fatalf("expected next document, but got %v", err)
+		}
+		want.Init([]byte(value))
+		expectSameWalk(t, want, p)
+	}
+	if err := p.NextDocument(); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
+
+func TestLinesNotASingleAllocAfterWarmUp(t *testing.T) {
+	p := NewLinesParser()
+	pool := p.(*jsonParser).pool
+	var line []byte
+	testrun.NotASingleAllocAfterWarmUp(t, pool, func(bs []byte) {
+		line = append(line[:0], bs...)
+		for i := range line {
+			if line[i] == '\n' {
+				line[i] = ' '
+			}
+		}
+		p.Init(line)
+		for {
+			if err := p.NextDocument(); err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err := debug.Walk(p); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,49 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func TestSkipBOM(t *testing.T) {
+	input := "\xEF\xBB\xBF{\"a\":1}"
+	p := NewParser(WithSkipBOM())
+	p.Init([]byte(input))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+
+	p = NewReaderParser(iotest.OneByteReader(strings.NewReader(input)), WithSkipBOM())
+	if err := walk(p); err != nil {
+		t.Fatal(err)
+	}
+
+	// By default a byte order mark is a syntax error.
+	p = NewParser()
+	p.Init([]byte(input))
+	if err := walk(p); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,64 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/scan"
+	"github.com/katydid/parser-go/parse/debug"
+)
+
+func nested(depth int) []byte {
+	return []byte(strings.Repeat(`[{"a":`, depth) + "1" + strings.Repeat("}]", depth))
+}
+
+func TestMaxDepth(t *testing.T) {
+	p := NewParser(WithMaxDepth(6))
+	p.Init(nested(3))
+	if err := debug.Walk(p); err != nil {
+		t.Fatal(err)
+	}
+	p.Init(nested(4))
+	err := debug.Walk(p)
+	syntaxErr := expectSyntaxError(t, err, ErrMaxDepthExceeded)
+	if want := len(`[{"a":[{"a":[{"a":`); syntaxErr.Offset != want {
+		t.Fatalf("want offset %d, but got %d", want, syntaxErr.Offset)
+	}
+	if syntaxErr.Found != scan.ArrayOpenKind {
+		t.Fatalf("want found %v, but got %v", scan.ArrayOpenKind, syntaxErr.Found)
+	}
+}
+
+func TestMaxDepthSkip(t *testing.T) {
+	p := NewParser(WithMaxDepth(6))
+	p.Init(nested(4))
+	if _, err := p.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Skip(); !errors.Is(err, ErrMaxDepthExceeded) {
+		t.Fatalf("expected %v, but got %v", ErrMaxDepthExceeded, err)
+	}
+}
+
+func TestMaxDepthUnlimited(t *testing.T) {
+	p := NewParser()
+	p.Init(nested(10000))
+	if err := debug.Walk(p); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,162 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"io"
+	"strconv"
+)
+
+// documents is the framing of the documents in the input.
+type documents byte
+
+// singleDocument is the default, where the input contains a single JSON value.
+const singleDocument = documents(0)
+
+// lineDocuments is for newline delimited JSON, where each line contains a JSON value.
+const lineDocuments = documents('\n')
+
+// sequenceDocuments is for JSON text sequences (RFC 7464), where each JSON value is preceded by a record separator.
+const sequenceDocuments = documents(recordSeparator)
+
+// concatenatedDocuments is for concatenated JSON values, where a document ends where its value ends.
+const concatenatedDocuments = documents('c')
+
+// recordSeparator is the ASCII RS character, which precedes each record in a JSON text sequence.
+const recordSeparator = 0x1E
+
+// RecordError is returned when parsing multiple documents and the document with the given record number is malformed.
+// Records are numbered from 1.
+type RecordError struct {
+	Record int
+	Err    error
+}
+
+func (e *RecordError) Error() string {
+	return "record " + strconv.Itoa(e.Record) + ": " + e.Err.Error()
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// NextDocument moves the parser to the start of the next document, skipping whatever is left of the current document.
+// It returns io.EOF if there are no more documents.
+// When the input contains a single document, the first call succeeds and all following calls return io.EOF.
+func (p *parser) NextDocument() error {
+	switch p.documents {
+	case singleDocument:
+		if p.record > 0 {
+			return io.EOF
+		}
+		p.record++
+		return nil
+	case concatenatedDocuments:
+		return p.nextConcatenatedDocument()
+	}
+	if p.err != nil && !p.continueAfterError {
+		return p.err
+	}
+	if p.documents == sequenceDocuments && !p.prefixed {
+		// Each record is preceded by a record separator, so there should only be whitespace before the first one.
+		blank, err := p.tokenizer.NextRecord(byte(p.documents))
+		if err != nil {
+			return err
+		}
+		if !blank {
+			p.err = &RecordError{Record: 0, Err: errExpectedRecordSeparator}
+			return p.err
+		}
+		p.prefixed = true
+	}
+	for {
+		blank, err := p.tokenizer.NextRecord(byte(p.documents))
+		if err != nil {
+			return err
+		}
+		p.record++
+		p.err = nil
+		p.blank = blank
+		p.Reset()
+		if !blank {
+			return nil
+		}
+		// Consecutive record separators do not denote empty records in a JSON text sequence.
+		if !p.skipBlankRecords && p.documents != sequenceDocuments {
+			return nil
+		}
+	}
+}
+
+// nextConcatenatedDocument skips the rest of the current value and then checks whether another value follows.
+// A malformed value cannot be recovered from, since there are no separators between values.
+func (p *parser) nextConcatenatedDocument() error {
+	if p.err != nil {
+		return p.err
+	}
+	if p.record > 0 {
+		if err := p.skipDocument(); err != nil {
+			return p.recordError(err)
+		}
+	}
+	if _, err := p.tokenizer.Peek(); err != nil {
+		return p.recordError(err)
+	}
+	p.record++
+	p.Reset()
+	return nil
+}
+
+// skipDocument skips the rest of the current document.
+// The state is only changed to endState, once the whole document has been skipped,
+// so that calling skipDocument again after more input is needed, resumes where it stopped.
+func (p *parser) skipDocument() error {
+	switch p.state {
+	case leafState, endState:
+		return nil
+	case startState:
+		if _, err := p.next(); err != nil {
+			return err
+		}
+	}
+	if err := p.skipUntil(0); err != nil {
+		return err
+	}
+	p.state = endState
+	return nil
+}
+
+// startDocument implicitly moves to the first document and checks that the current document is not blank.
+func (p *parser) startDocument() error {
+	if p.record == 0 {
+		if err := p.NextDocument(); err != nil {
+			return err
+		}
+	}
+	if p.blank && p.state == startState {
+		return p.recordError(errBlankRecord)
+	}
+	return nil
+}
+
+// recordError annotates syntax errors with the current record number, when parsing multiple documents.
+// io.EOF and ErrNeedMoreInput are not annotated, since they do not mean the document is malformed.
+func (p *parser) recordError(err error) error {
+	if err == nil || err == io.EOF || err == ErrNeedMoreInput || p.documents == singleDocument {
+		return err
+	}
+	p.err = &RecordError{Record: p.record, Err: err}
+	return p.err
+}
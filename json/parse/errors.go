@@ -14,14 +14,69 @@
 
 package parse
 
-import "errors"
+import (
+	"errors"
+	"strconv"
 
-var errExpectedValue = errors.New("expected value")
+	"github.com/katydid/parser-go-json/json/scan"
+	"github.com/katydid/parser-go-json/json/token"
+)
 
-var errExpectedCommaOrCloseBracket = errors.New("expected ',' or ']'")
+var errUnexpectedClose = errors.New("unexpected `}` or `]`")
 
-var errExpectedStringOrCloseCurly = errors.New("expected '\"' or '}'")
+var errBlankRecord = errors.New("blank record")
 
-var errExpectedColon = errors.New("expected ':'")
+var errExpectedRecordSeparator = errors.New("expected record separator")
 
-var errUnexpectedClose = errors.New("unexpected `}` or `]`")
+// ErrMaxDepthExceeded is returned by Next when arrays and objects are nested deeper than the maximum set using WithMaxDepth.
+var ErrMaxDepthExceeded = scan.ErrMaxDepthExceeded
+
+// ErrTooManyMembers is returned by Next when an object has more fields than the maximum set using WithMaxObjectMembers.
+var ErrTooManyMembers = errors.New("object exceeds the maximum number of members")
+
+// ErrTooManyElements is returned by Next when an array has more elements than the maximum set using WithMaxArrayElements.
+var ErrTooManyElements = errors.New("array exceeds the maximum number of elements")
+
+// ErrDuplicateKey matches a *DuplicateKeyError using errors.Is.
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// DuplicateKeyError is returned when an object contains the same key more than once, see WithRejectDuplicateKeys.
+type DuplicateKeyError struct {
+	// Key is the unquoted key.
+	Key string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return "duplicate key " + strconv.Quote(e.Key)
+}
+
+func (e *DuplicateKeyError) Is(target error) bool {
+	return target == ErrDuplicateKey
+}
+
+// ErrInputTooLarge is returned by Next when the input is larger than the maximum set using WithMaxInputBytes.
+var ErrInputTooLarge = scan.ErrInputTooLarge
+
+// ErrStringTooLong is returned when a string is longer than the maximum set using WithMaxStringBytes.
+var ErrStringTooLong = scan.ErrStringTooLong
+
+// ErrNumberTooLong is returned when a number has more digits than the maximum set using WithMaxNumberDigits.
+var ErrNumberTooLong = scan.ErrNumberTooLong
+
+// ErrInvalidUTF8 is returned when a string contains invalid UTF-8, see WithStrictUTF8.
+var ErrInvalidUTF8 = scan.ErrInvalidUTF8
+
+// ErrLoneSurrogate is returned when a string contains an escaped surrogate that is not part of a surrogate pair, see WithStrictUTF8.
+var ErrLoneSurrogate = scan.ErrLoneSurrogate
+
+// ErrUnsafeInteger is returned when an integer cannot be represented exactly by an IEEE 754 double, see WithSafeIntegers.
+var ErrUnsafeInteger = scan.ErrUnsafeInteger
+
+// ErrUnterminatedComment is returned when a multi-line comment is not closed before the end of the input, see WithComments.
+var ErrUnterminatedComment = scan.ErrUnterminatedComment
+
+// ErrNotUint is returned by Uint when the current value is not an integer between 0 and math.MaxUint64.
+var ErrNotUint = token.ErrNotUint
+
+// ErrNeedMoreInput is returned by Next when the end of the fed input has been reached, but Close has not been called yet.
+var ErrNeedMoreInput = scan.ErrNeedMoreInput
@@ -0,0 +1,50 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/token"
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func TestExactNumbers(t *testing.T) {
+	p := NewParser(WithExactNumbers())
+	p.Init([]byte(`[0.1, 100000000000000000000000000001, 2]`))
+	expect.Hint(t, p, parse.EnterHint)
+	want := []string{"1/10", "100000000000000000000000000001/1", "2/1"}
+	r := new(big.Rat)
+	for _, w := range want {
+		expect.Hint(t, p, parse.ValueHint)
+		kind, value, err := p.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind != parse.DecimalKind {
+			t.Fatalf("want decimal, but got %v", kind)
+		}
+		if _, err := token.BigRat(r, kind, value); err != nil {
+			t.Fatal(err)
+		}
+		if r.String() != w {
+			t.Fatalf("want %s, but got %s", w, r)
+		}
+	}
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
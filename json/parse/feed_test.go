@@ -0,0 +1,168 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"io"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+	"github.com/katydid/parser-go/pool"
+)
+
+func expectNeedMoreInput(t *testing.T, p Parser) {
+	t.Helper()
+	if h, err := p.Next(); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v with hint %v", err, h)
+	}
+}
+
+func TestFeedExample(t *testing.T) {
+	p := NewParser()
+	p.Feed([]byte(`{"num":3.1`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "num")
+	expectNeedMoreInput(t, p)
+	p.Feed([]byte(`4,"arr":[null,`))
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Float(t, p, 3.14)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "arr")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expectNeedMoreInput(t, p)
+	p.Feed([]byte(`fa`))
+	expectNeedMoreInput(t, p)
+	p.Feed([]byte(`lse],"k"`))
+	expect.Hint(t, p, parse.ValueHint)
+	expect.False(t, p)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "k")
+	expectNeedMoreInput(t, p)
+	p.Feed([]byte(`:`))
+	expectNeedMoreInput(t, p)
+	p.Feed([]byte(` "v"}`))
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "v")
+	expect.Hint(t, p, parse.LeaveHint)
+	expectNeedMoreInput(t, p)
+	p.Close()
+	expect.EOF(t, p)
+}
+
+func TestFeedSkip(t *testing.T) {
+	p := NewParser()
+	p.Feed([]byte(`{"a":[1,[2,`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	if err := p.Skip(); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	p.Feed([]byte(`3]],"b":2}`))
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "b")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 2)
+	expect.Hint(t, p, parse.LeaveHint)
+	p.Close()
+	expect.EOF(t, p)
+}
+
+func TestFeedNumberAtEndOfChunk(t *testing.T) {
+	p := NewParser()
+	p.Feed([]byte(`12`))
+	// The number might continue in the next chunk.
+	expectNeedMoreInput(t, p)
+	p.Feed([]byte(`3`))
+	expectNeedMoreInput(t, p)
+	p.Close()
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 123)
+	expect.EOF(t, p)
+}
+
+func TestFeedInvalidAfterClose(t *testing.T) {
+	p := NewParser()
+	p.Feed([]byte(`[1,`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expectNeedMoreInput(t, p)
+	p.Close()
+	expect.Err(t, p.Next)
+}
+
+// feedWalk walks the parser, while feeding it chunks of the input of random sizes.
+func feedWalk(r rand.Rand, p Parser, input []byte) error {
+	p.Init(nil)
+	fed := 0
+	feed := func() {
+		if fed == len(input) {
+			p.Close()
+			return
+		}
+		size := min(1+r.Intn(8), len(input)-fed)
+		p.Feed(input[fed : fed+size])
+		fed += size
+	}
+	feed()
+	for {
+		hint, err := p.Next()
+		if err == ErrNeedMoreInput {
+			feed()
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		switch hint {
+		case parse.ValueHint, parse.FieldHint:
+			if err := walkValue(p); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func TestFeedRandomValues(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	for _, value := range values {
+		name := testrun.Name(value)
+		t.Run(name, func(t *testing.T) {
+			p := NewParser()
+			if err := feedWalk(r, p, value); err != io.EOF {
+				t.Fatalf("expected EOF, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestFeedNotASingleAllocAfterWarmUp(t *testing.T) {
+	pool := pool.New()
+	p := NewParser(WithAllocator(pool.Alloc))
+	r := rand.NewRand()
+	testrun.NotASingleAllocAfterWarmUp(t, pool, func(bs []byte) {
+		if err := feedWalk(r, p, bs); err != io.EOF {
+			t.Fatalf("expected EOF, but got %v", err)
+		}
+	})
+}
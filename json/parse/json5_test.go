@@ -0,0 +1,226 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"io"
+	"math"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func TestJSON5(t *testing.T) {
+	p := NewParser(WithJSON5())
+	p.Init([]byte(`// comment
+{
+	unquoted: 'and you can quote me on that',
+	singleQuotes: 'I can use "double quotes" here',
+	lineBreaks: "Look, Mom! \
+No \\n's!",
+	hexadecimal: 0xdecaf,
+	leadingDecimalPoint: .8675309, andTrailing: 8675309.,
+	positiveSign: +1,
+	/* trailing comma */
+	trailingComma: 'in objects', andIn: ['arrays',],
+	"backwardsCompatible": "with JSON",
+	infinity: -Infinity,
+	nan: NaN,
+}`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "unquoted")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "and you can quote me on that")
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "singleQuotes")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, `I can use "double quotes" here`)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "lineBreaks")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "Look, Mom! No \\n's!")
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "hexadecimal")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 0xdecaf)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "leadingDecimalPoint")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Float(t, p, .8675309)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "andTrailing")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Float(t, p, 8675309)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "positiveSign")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "trailingComma")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "in objects")
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "andIn")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "arrays")
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "backwardsCompatible")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "with JSON")
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "infinity")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Float(t, p, math.Inf(-1))
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "nan")
+	expect.Hint(t, p, parse.ValueHint)
+	kind, _, err := p.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != parse.Float64Kind {
+		t.Fatalf("want float, but got %v", kind)
+	}
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestJSON5TrailingCommas(t *testing.T) {
+	valid := []string{
+		`[1,]`,
+		`[[],]`,
+		`{a:1,}`,
+		`{a:{b:[],},}`,
+		`[{},{},]`,
+	}
+	invalid := []string{
+		`[,]`,
+		`[1,,]`,
+		`{,}`,
+		`{a:1,,}`,
+		`[1,}`,
+		`{a:1,]`,
+	}
+	p := NewParser(WithJSON5())
+	for _, input := range valid {
+		p.Init([]byte(input))
+		if err := walk(p); err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+	}
+	for _, input := range invalid {
+		p.Init([]byte(input))
+		if err := walk(p); err == nil {
+			t.Fatalf("%s: expected error", input)
+		}
+	}
+	// Trailing commas are still invalid in JSON.
+	p = NewParser()
+	for _, input := range valid {
+		p.Init([]byte(input))
+		if err := walk(p); err == nil {
+			t.Fatalf("%s: expected error", input)
+		}
+	}
+}
+
+func TestJSON5Invalid(t *testing.T) {
+	invalid := []string{
+		`{1: 1}`,
+		`[abc]`,
+		"'a\nb'",
+		`[01]`,
+		`[0x]`,
+		`/* unterminated`,
+		`{a b: 1}`,
+	}
+	p := NewParser(WithJSON5())
+	for _, input := range invalid {
+		p.Init([]byte(input))
+		if err := walk(p); err == nil {
+			t.Fatalf("%s: expected error", input)
+		}
+	}
+}
+
+func TestJSON5KeywordKeys(t *testing.T) {
+	p := NewParser(WithJSON5())
+	p.Init([]byte(`{null: true, true: 1, false: Infinity, Infinity: NaN, NaN: false}`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "null")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.True(t, p)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "true")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "false")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Float(t, p, math.Inf(1))
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "Infinity")
+	if err := p.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "NaN")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.False(t, p)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+
+	// Keywords are only keys in JSON5.
+	p = NewParser()
+	p.Init([]byte(`{null: 1}`))
+	if err := walk(p); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestJSON5Skip(t *testing.T) {
+	p := NewParser(WithJSON5())
+	p.Init([]byte(`{a: [1, 'b', {c: 0x1,},], // d
+		d: 'e'}`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	if err := p.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "d")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "e")
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestJSON5Feed(t *testing.T) {
+	input := []byte(`// comment
+{unquoted: 'single', hex: 0xFF, half: .5, positive: +Infinity, nan: NaN, /* block */ end: true,
+	nested: [null, false, 1., ], \u0061b: "c",}
+`)
+	p := NewParser(WithJSON5())
+	if err := feedWalk(rand.NewRand(), p, input); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
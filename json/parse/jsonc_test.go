@@ -0,0 +1,130 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"io"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func expectComment(t *testing.T, p Parser, want string) {
+	t.Helper()
+	if got := string(p.Comment()); got != want {
+		t.Fatalf("want comment %q, but got %q", want, got)
+	}
+}
+
+func TestJSONC(t *testing.T) {
+	p := NewParser(WithJSONC())
+	p.Init([]byte(`{
+	// The target version.
+	"target": "es2020",
+	"paths": [
+		"src", /* generated */
+		"gen",
+	],
+}
+`))
+	expect.Hint(t, p, parse.EnterHint)
+	expectComment(t, p, "")
+	expect.Hint(t, p, parse.FieldHint)
+	expectComment(t, p, "// The target version.")
+	expect.String(t, p, "target")
+	expect.Hint(t, p, parse.ValueHint)
+	expectComment(t, p, "")
+	expect.String(t, p, "es2020")
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "paths")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "src")
+	expect.Hint(t, p, parse.ValueHint)
+	expectComment(t, p, "/* generated */")
+	expect.String(t, p, "gen")
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestComments(t *testing.T) {
+	p := NewParser(WithComments())
+	p.Init([]byte(`/* a */ [1, // b
+	2]`))
+	expect.Hint(t, p, parse.EnterHint)
+	expectComment(t, p, "/* a */")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.ValueHint)
+	expectComment(t, p, "// b")
+	expect.Int(t, p, 2)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+
+	// Comments do not allow trailing commas.
+	p.Init([]byte(`[1,]`))
+	if err := walk(p); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestTrailingCommas(t *testing.T) {
+	p := NewParser(WithTrailingCommas())
+	p.Init([]byte(`{"a":[1,2,],"b":{},}`))
+	if err := walk(p); err != nil {
+		t.Fatal(err)
+	}
+	// Trailing commas do not allow comments.
+	p.Init([]byte(`[1, /* 2 */]`))
+	if err := walk(p); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestWithoutComments(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(`// a
+	1`))
+	if err := walk(p); err == nil {
+		t.Fatal("expected comments to be invalid JSON")
+	}
+}
+
+func TestJSONCUnterminatedComment(t *testing.T) {
+	p := NewParser(WithJSONC())
+	p.Init([]byte(`[1, /* 2`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	_, err := p.Next()
+	syntaxErr := expectSyntaxError(t, err, ErrUnterminatedComment)
+	if syntaxErr.Offset != 4 {
+		t.Fatalf("want offset 4, but got %d", syntaxErr.Offset)
+	}
+}
+
+func TestJSONCFeed(t *testing.T) {
+	input := []byte(`// a
+{"a" /* b */ : /* c
+*/ [true, /**/ null, ], // d
+"e": {},
+}//`)
+	p := NewParser(WithJSONC())
+	if err := feedWalk(rand.NewRand(), p, input); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
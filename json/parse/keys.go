@@ -0,0 +1,132 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import "hash/maphash"
+
+// keySet is a hash set of the keys of an object, which keeps its memory when it is reset,
+// so that it can be reused without allocating.
+type keySet struct {
+	// seed is the seed of the hashes of the keys, which is random, so that the slots of keys cannot be predicted.
+	seed maphash.Seed
+	// keys contains the bytes of all the keys in the set.
+	keys []byte
+	// entries are the keys in the set.
+	entries []keyEntry
+	// table is an open addressing hash table of indexes into entries plus one, where zero means that the slot is empty.
+	table []int32
+}
+
+type keyEntry struct {
+	hash  uint64
+	start int
+	end   int
+	// slot is the index into the table, so that the slot can be cleared on reset.
+	slot int
+}
+
+const minKeySetTableSize = 16
+
+// reset empties the set, but keeps its memory.
+// Only the slots that were used are cleared, so that resetting a small set is cheap, even if its table is large.
+func (s *keySet) reset() {
+	for _, e := range s.entries {
+		s.table[e.slot] = 0
+	}
+	s.entries = s.entries[:0]
+	s.keys = s.keys[:0]
+}
+
+// add adds the key to the set and returns false if the key was already in the set.
+func (s *keySet) add(key []byte) bool {
+	if 2*(len(s.entries)+1) > len(s.table) {
+		s.grow()
+	}
+	hash := maphash.Bytes(s.seed, key)
+	slot := s.find(hash, key)
+	if s.table[slot] != 0 {
+		return false
+	}
+	start := len(s.keys)
+	s.keys = append(s.keys, key...)
+	s.entries = append(s.entries, keyEntry{hash: hash, start: start, end: len(s.keys), slot: slot})
+	s.table[slot] = int32(len(s.entries))
+	return true
+}
+
+// find returns the slot of the key or the empty slot where it should be added.
+func (s *keySet) find(hash uint64, key []byte) int {
+	mask := len(s.table) - 1
+	slot := int(hash) & mask
+	for {
+		index := s.table[slot]
+		if index == 0 {
+			return slot
+		}
+		e := s.entries[index-1]
+		if e.hash == hash && string(s.keys[e.start:e.end]) == string(key) {
+			return slot
+		}
+		slot = (slot + 1) & mask
+	}
+}
+
+// grow doubles the size of the table and adds the existing entries to it again.
+func (s *keySet) grow() {
+	size := max(2*len(s.table), minKeySetTableSize)
+	s.table = make([]int32, size)
+	for i := range s.entries {
+		e := &s.entries[i]
+		e.slot = s.find(e.hash, s.keys[e.start:e.end])
+		s.table[e.slot] = int32(i + 1)
+	}
+}
+
+// keySets is a pool of key sets, one for each level of nesting,
+// that are reused for the next object at the same depth.
+type keySets struct {
+	sets  []keySet
+	depth int
+	// seed is shared by all the key sets of a parser.
+	seed maphash.Seed
+}
+
+func newKeySets() keySets {
+	return keySets{seed: maphash.MakeSeed()}
+}
+
+// push starts an empty key set for the next level of nesting.
+func (k *keySets) push() {
+	if k.depth == len(k.sets) {
+		k.sets = append(k.sets, keySet{seed: k.seed})
+	}
+	k.sets[k.depth].reset()
+	k.depth++
+}
+
+// pop returns the key set of the current level of nesting to the pool.
+func (k *keySets) pop() {
+	k.depth--
+}
+
+// top returns the key set of the current level of nesting.
+func (k *keySets) top() *keySet {
+	return &k.sets[k.depth-1]
+}
+
+// reset returns all the key sets to the pool.
+func (k *keySets) reset() {
+	k.depth = 0
+}
@@ -0,0 +1,138 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"strings"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go/parse/debug"
+	"github.com/katydid/parser-go/pool"
+)
+
+func TestRejectDuplicateKeys(t *testing.T) {
+	p := NewParser(WithRejectDuplicateKeys())
+	p.Init([]byte(`{"X":{},"Y":{},"X":{}}`))
+	syntaxErr := expectSyntaxError(t, debug.Walk(p), ErrDuplicateKey)
+	if syntaxErr.Offset != 15 {
+		t.Fatalf("want offset 15, but got %d", syntaxErr.Offset)
+	}
+	var dupErr *DuplicateKeyError
+	if !errors.As(syntaxErr, &dupErr) {
+		t.Fatalf("expected duplicate key error, but got %v", syntaxErr)
+	}
+	if dupErr.Key != "X" {
+		t.Fatalf("want key X, but got %q", dupErr.Key)
+	}
+	if !strings.Contains(syntaxErr.Error(), `duplicate key "X"`) {
+		t.Fatalf("expected the error to name the key, but got %v", syntaxErr)
+	}
+}
+
+func TestRejectDuplicateEscapedKeys(t *testing.T) {
+	p := NewParser(WithRejectDuplicateKeys())
+	p.Init([]byte(`{"a":1,"\u0061":2}`))
+	expectSyntaxError(t, debug.Walk(p), ErrDuplicateKey)
+}
+
+func TestRejectDuplicateKeysWhileSkipping(t *testing.T) {
+	p := NewParser(WithRejectDuplicateKeys())
+	p.Init([]byte(`[{"a":1,"b":{"c":1,"c":2}}]`))
+	if _, err := p.Next(); err != nil {
+		t.Fatal(err)
+	}
+	expectSyntaxError(t, p.Skip(), ErrDuplicateKey)
+}
+
+func TestAcceptUniqueKeys(t *testing.T) {
+	inputs := []string{
+		`{"a":{"a":{"a":1}},"b":[{"a":1},{"a":2}],"c":{"b":1}}`,
+		`[{"a":1,"b":2},{"a":1,"b":2}]`,
+		`{"a":[{"a":1}],"b":[]}`,
+	}
+	p := NewParser(WithRejectDuplicateKeys())
+	for _, input := range inputs {
+		p.Init([]byte(input))
+		if err := debug.Walk(p); err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+	}
+}
+
+func TestRejectDuplicateKeysInLargeObject(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("{")
+	for i := range 1000 {
+		fmt.Fprintf(&sb, `"k%d":%d,`, i, i)
+	}
+	sb.WriteString(`"k999":0}`)
+	p := NewParser(WithRejectDuplicateKeys())
+	p.Init([]byte(sb.String()))
+	var dupErr *DuplicateKeyError
+	if err := debug.Walk(p); !errors.As(err, &dupErr) || dupErr.Key != "k999" {
+		t.Fatalf("expected duplicate key k999, but got %v", err)
+	}
+}
+
+func TestRejectDuplicateKeysInTheSameSlot(t *testing.T) {
+	p := NewParser(WithRejectDuplicateKeys())
+	seed := p.(*parser).keys.seed
+	// The table of 200 keys has 512 slots, so these keys all land in the first slot, just like in the smaller tables before it grew.
+	const n = 200
+	mask := uint64(511)
+	var sb strings.Builder
+	sb.WriteString("{")
+	first := ""
+	for i, found := 0, 0; found < n; i++ {
+		key := fmt.Sprintf("k%d", i)
+		if maphash.String(seed, key)&mask != 0 {
+			continue
+		}
+		if first == "" {
+			first = key
+		}
+		fmt.Fprintf(&sb, `"%s":%d,`, key, found)
+		found++
+	}
+	fmt.Fprintf(&sb, `"%s":0}`, first)
+	p.Init([]byte(sb.String()))
+	var dupErr *DuplicateKeyError
+	if err := debug.Walk(p); !errors.As(err, &dupErr) || dupErr.Key != first {
+		t.Fatalf("expected duplicate key %s, but got %v", first, err)
+	}
+}
+
+func TestRejectDuplicateKeysNotASingleAllocAfterWarmUp(t *testing.T) {
+	pool := pool.New()
+	p := NewParser(WithAllocator(pool.Alloc), WithRejectDuplicateKeys())
+	// Random values might contain duplicate keys, which are only parsed during the warm up, since errors allocate.
+	duplicates := make(map[string]bool)
+	testrun.NotASingleAllocAfterWarmUp(t, pool, func(bs []byte) {
+		if duplicates[string(bs)] {
+			return
+		}
+		p.Init(bs)
+		if err := walk(p); err != nil {
+			if !errors.Is(err, ErrDuplicateKey) {
+				t.Fatalf("expected EOF, but got %v", err)
+			}
+			duplicates[string(bs)] = true
+		}
+	})
+}
@@ -0,0 +1,99 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go/parse/debug"
+	"github.com/katydid/parser-go/pool"
+)
+
+func TestMaxMembersAndElements(t *testing.T) {
+	input := []byte(`{"a":[1,[2,3]],"b":{"c":[],"d":{}},"e":[4,5]}`)
+	p := NewParser(WithMaxObjectMembers(3), WithMaxArrayElements(2))
+	p.Init(input)
+	if err := debug.Walk(p); err != nil {
+		t.Fatal(err)
+	}
+	// The counts of nested objects and arrays are restored when feeding one chunk at a time.
+	if err := feedWalk(rand.NewRand(), p, input); err != io.EOF {
+		t.Fatal(err)
+	}
+
+	p.Init([]byte(`{"a":[1,[2,3,4]]}`))
+	syntaxErr := expectSyntaxError(t, debug.Walk(p), ErrTooManyElements)
+	if syntaxErr.Offset != 13 {
+		t.Fatalf("want offset 13, but got %d", syntaxErr.Offset)
+	}
+
+	p.Init([]byte(`[{"a":1,"b":2,"c":3,"d":4}]`))
+	syntaxErr = expectSyntaxError(t, debug.Walk(p), ErrTooManyMembers)
+	if syntaxErr.Offset != 20 {
+		t.Fatalf("want offset 20, but got %d", syntaxErr.Offset)
+	}
+}
+
+func TestMaxStringBytesAndNumberDigits(t *testing.T) {
+	p := NewParser(WithMaxStringBytes(3), WithMaxNumberDigits(3))
+	p.Init([]byte(`{"abc":123}`))
+	if err := debug.Walk(p); err != nil {
+		t.Fatal(err)
+	}
+	p.Init([]byte(`{"abc":1234}`))
+	expectSyntaxError(t, debug.Walk(p), ErrNumberTooLong)
+	p.Init([]byte(`{"abcd":123}`))
+	expectSyntaxError(t, debug.Walk(p), ErrStringTooLong)
+	// Skip also checks the limits.
+	p.Init([]byte(`["abcd"]`))
+	if _, err := p.Next(); err != nil {
+		t.Fatal(err)
+	}
+	expectSyntaxError(t, p.Skip(), ErrStringTooLong)
+}
+
+func TestMaxInputBytes(t *testing.T) {
+	p := NewParser(WithMaxInputBytes(8))
+	p.Init([]byte(`[1,2,3]`))
+	if err := debug.Walk(p); err != nil {
+		t.Fatal(err)
+	}
+	p.Init([]byte(`[1,2,3,4]`))
+	if err := debug.Walk(p); !errors.Is(err, ErrInputTooLarge) {
+		t.Fatalf("expected %v, but got %v", ErrInputTooLarge, err)
+	}
+}
+
+func TestLimitsNotASingleAllocAfterWarmUp(t *testing.T) {
+	pool := pool.New()
+	p := NewParser(
+		WithAllocator(pool.Alloc),
+		WithMaxInputBytes(1<<20),
+		WithMaxStringBytes(1<<10),
+		WithMaxNumberDigits(1<<10),
+		WithMaxObjectMembers(1<<10),
+		WithMaxArrayElements(1<<10),
+	)
+	testrun.NotASingleAllocAfterWarmUp(t, pool, func(bs []byte) {
+		p.Init(bs)
+		if err := walk(p); err != nil {
+			t.Fatalf("expected EOF, but got %v", err)
+		}
+	})
+}
@@ -0,0 +1,217 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func expectNextDocument(t *testing.T, p Parser) {
+	t.Helper()
+	if err := p.NextDocument(); err != nil {
+		t.Fatalf("expected next document, but got %v", err)
+	}
+}
+
+func expectNoMoreDocuments(t *testing.T, p Parser) {
+	t.Helper()
+	if err := p.NextDocument(); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
+
+func expectRecordError(t *testing.T, err error, record int) {
+	t.Helper()
+	var recordErr *RecordError
+	if !errors.As(err, &recordErr) {
+		t.Fatalf("expected record error, but got %v", err)
+	}
+	if recordErr.Record != record {
+		t.Fatalf("expected record %d, but got %d", record, recordErr.Record)
+	}
+}
+
+func TestLinesExample(t *testing.T) {
+	p := NewParser(WithLines())
+	p.Init([]byte("{\"a\":1}\n[true, null]\r\n\"s\"\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.True(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "s")
+	expect.EOF(t, p)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestLinesImplicitFirstDocument(t *testing.T) {
+	p := NewParser(WithLines())
+	p.Init([]byte("1\n2"))
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 2)
+	expect.EOF(t, p)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestLinesSkipRestOfDocument(t *testing.T) {
+	p := NewParser(WithLines())
+	p.Init([]byte("{\"a\":[1,2],\"b\":3}\n{\"c\":4}\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "c")
+	expect.NoErr(t, p.Skip)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestLinesValueSpanningLines(t *testing.T) {
+	p := NewParser(WithLines())
+	p.Init([]byte("[1,\n2]\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	_, err := p.Next()
+	expectRecordError(t, err, 1)
+}
+
+func TestLinesMalformedRecord(t *testing.T) {
+	p := NewParser(WithLines())
+	p.Init([]byte("1\n{]\n3\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	_, err := p.Next()
+	expectRecordError(t, err, 2)
+	// Without WithContinueAfterError, the error is sticky.
+	expectRecordError(t, p.NextDocument(), 2)
+}
+
+func TestLinesTrailingValue(t *testing.T) {
+	p := NewParser(WithLines())
+	p.Init([]byte("1 2\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	_, err := p.Next()
+	expectRecordError(t, err, 1)
+}
+
+func TestLinesContinueAfterError(t *testing.T) {
+	p := NewParser(WithLines(), WithContinueAfterError())
+	p.Init([]byte("1\n{]\n3\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	_, err := p.Next()
+	expectRecordError(t, err, 2)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 3)
+	expect.EOF(t, p)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestLinesBlankLine(t *testing.T) {
+	p := NewParser(WithLines())
+	p.Init([]byte("1\n \n3\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expectNextDocument(t, p)
+	_, err := p.Next()
+	expectRecordError(t, err, 2)
+	if !errors.Is(err, errBlankRecord) {
+		t.Fatalf("expected blank record error, but got %v", err)
+	}
+}
+
+func TestLinesSkipBlankLines(t *testing.T) {
+	p := NewParser(WithLines(), WithSkipBlankLines())
+	p.Init([]byte("\n1\n \n\n3\n\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 3)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestLinesFeed(t *testing.T) {
+	p := NewParser(WithLines())
+	p.Feed([]byte(`{"a":`))
+	if err := p.NextDocument(); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	p.Feed([]byte("1}\n[tr"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	if err := p.NextDocument(); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	p.Feed([]byte("ue]"))
+	p.Close()
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.True(t, p)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestSingleDocument(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte("1"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.EOF(t, p)
+	expectNoMoreDocuments(t, p)
+}
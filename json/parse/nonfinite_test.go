@@ -0,0 +1,103 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"io"
+	"math"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func TestNonFiniteNumbers(t *testing.T) {
+	p := NewParser(WithNonFiniteNumbers())
+	// The output of Python's json.dumps({"a": [float("nan"), float("inf"), float("-inf"), 1]})
+	p.Init([]byte(`{"a": [NaN, Infinity, -Infinity, 1]}`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	kind, _, err := p.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != parse.Float64Kind {
+		t.Fatalf("want float, but got %v", kind)
+	}
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Float(t, p, math.Inf(1))
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Float(t, p, math.Inf(-1))
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestNonFiniteNumbersInvalid(t *testing.T) {
+	invalid := []string{
+		`[-NaN]`,
+		`[+Infinity]`,
+		`[Inf]`,
+		`[NaNa]`,
+		`[nan]`,
+	}
+	p := NewParser(WithNonFiniteNumbers())
+	for _, input := range invalid {
+		p.Init([]byte(input))
+		if err := walk(p); err == nil {
+			t.Fatalf("%s: expected error", input)
+		}
+		// Skip also scans the number.
+		p.Init([]byte(input))
+		expect.Hint(t, p, parse.EnterHint)
+		if err := p.Skip(); err == nil {
+			t.Fatalf("%s: expected error when skipping", input)
+		}
+	}
+	// Non-finite numbers are not valid JSON.
+	p = NewParser()
+	for _, input := range []string{`[NaN]`, `[Infinity]`, `[-Infinity]`} {
+		p.Init([]byte(input))
+		if err := walk(p); err == nil {
+			t.Fatalf("%s: expected error", input)
+		}
+	}
+}
+
+func TestNonFiniteNumbersSafeIntegers(t *testing.T) {
+	p := NewParser(WithNonFiniteNumbers(), WithSafeIntegers())
+	p.Init([]byte(`[NaN, -Infinity, 1]`))
+	if err := walk(p); err != nil {
+		t.Fatal(err)
+	}
+	p.Init([]byte(`[NaN, 9007199254740992]`))
+	if err := walk(p); err == nil {
+		t.Fatal("expected unsafe integer")
+	}
+}
+
+func TestNonFiniteNumbersFeed(t *testing.T) {
+	input := []byte(`{"a": [NaN, Infinity, -Infinity, 1.5], "b": NaN, "c": -Infinity}`)
+	p := NewParser(WithNonFiniteNumbers())
+	if err := feedWalk(rand.NewRand(), p, input); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
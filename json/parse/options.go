@@ -14,11 +14,27 @@
 
 package parse
 
+import "github.com/katydid/parser-go-json/json/scan"
+
 type options struct {
 	tags  bool
 	index bool
 	alloc func(int) []byte
 	buf   []byte
+
+	documents          documents
+	skipBlankRecords   bool
+	continueAfterError bool
+
+	maxDepth    int
+	mode        scan.Mode
+	limits      scan.Limits
+	maxMembers  int
+	maxElements int
+
+	rejectDuplicateKeys bool
+	trailingCommas      bool
+	exactNumbers        bool
 }
 
 func newOptions(opts ...Option) *options {
@@ -45,8 +61,206 @@ func WithAllocator(alloc func(int) []byte) func(*options) {
 }
 
 // WithBuffer passes in a buffer to parse.
+// Without a buffer, the parser waits for input to be fed, so Next returns ErrNeedMoreInput until Close is called.
 func WithBuffer(buf []byte) func(*options) {
 	return func(o *options) {
 		o.buf = buf
 	}
 }
+
+// WithLines parses newline delimited JSON (JSON Lines or NDJSON), where each line is a separate document.
+// Use NextDocument to move to the next line.
+func WithLines() func(*options) {
+	return func(o *options) {
+		o.documents = lineDocuments
+	}
+}
+
+// WithSequence parses JSON text sequences (RFC 7464), where each JSON value is preceded by a record separator (0x1E).
+// Use NextDocument to move to the next record.
+// Consecutive record separators are skipped.
+func WithSequence() func(*options) {
+	return func(o *options) {
+		o.documents = sequenceDocuments
+	}
+}
+
+// WithConcatenated parses concatenated JSON values, for example `{"a":1}{"b":2}` or `1 2 3`.
+// Use NextDocument to move to the next value.
+// Unlike records, a malformed value cannot be recovered from, so WithContinueAfterError has no effect.
+func WithConcatenated() func(*options) {
+	return func(o *options) {
+		o.documents = concatenatedDocuments
+	}
+}
+
+// WithSkipBlankLines skips over lines that only contain whitespace, instead of returning an error.
+// This is only relevant when parsing multiple documents, for example using WithLines.
+func WithSkipBlankLines() func(*options) {
+	return func(o *options) {
+		o.skipBlankRecords = true
+	}
+}
+
+// WithContinueAfterError allows NextDocument to move on to the next document after a malformed document.
+// By default NextDocument keeps on returning the error of the malformed document.
+// This is only relevant when parsing multiple documents, for example using WithLines.
+func WithContinueAfterError() func(*options) {
+	return func(o *options) {
+		o.continueAfterError = true
+	}
+}
+
+// WithMaxDepth limits the nesting depth of arrays and objects to the given maximum.
+// Next returns ErrMaxDepthExceeded when the input is nested deeper, before growing the stack any further.
+// A maximum of zero, the default, means that the depth is not limited.
+func WithMaxDepth(max int) func(*options) {
+	return func(o *options) {
+		o.maxDepth = max
+	}
+}
+
+// WithMaxInputBytes limits the size of the whole input, whether it is passed to Init, read using InitReader or fed using Feed.
+// Next returns ErrInputTooLarge when the limit is exceeded.
+func WithMaxInputBytes(max int) func(*options) {
+	return func(o *options) {
+		o.limits.MaxInputBytes = max
+	}
+}
+
+// WithMaxStringBytes limits the number of bytes between the quotes of a string, before it is unquoted.
+// Next, Skip or Token return ErrStringTooLong, before the string is unquoted.
+// When reading or feeding input, a string that is too long is not read into memory any further.
+func WithMaxStringBytes(max int) func(*options) {
+	return func(o *options) {
+		o.limits.MaxStringBytes = max
+	}
+}
+
+// WithMaxNumberDigits limits the number of digits of a number, including the digits of its fraction and exponent.
+// Next, Skip or Token return ErrNumberTooLong, before the number is parsed.
+func WithMaxNumberDigits(max int) func(*options) {
+	return func(o *options) {
+		o.limits.MaxNumberDigits = max
+	}
+}
+
+// WithMaxObjectMembers limits the number of fields of each object.
+// Next returns ErrTooManyMembers when the limit is exceeded.
+func WithMaxObjectMembers(max int) func(*options) {
+	return func(o *options) {
+		o.maxMembers = max
+	}
+}
+
+// WithMaxArrayElements limits the number of elements of each array.
+// Next returns ErrTooManyElements when the limit is exceeded.
+func WithMaxArrayElements(max int) func(*options) {
+	return func(o *options) {
+		o.maxElements = max
+	}
+}
+
+// WithRejectDuplicateKeys returns an error when an object contains the same key more than once.
+// Keys are compared after they are unquoted, so `{"a":1,"\u0061":2}` is also rejected.
+// Next or Skip returns a *DuplicateKeyError, wrapped in a *scan.SyntaxError with the position of the duplicate key,
+// which matches ErrDuplicateKey using errors.Is.
+func WithRejectDuplicateKeys() func(*options) {
+	return func(o *options) {
+		o.rejectDuplicateKeys = true
+	}
+}
+
+// WithStrictUTF8 rejects strings, including keys, that contain invalid UTF-8 or escaped surrogates that are not part of a surrogate pair,
+// instead of replacing them with the Unicode replacement character.
+// Next, Skip or Token return ErrInvalidUTF8 or ErrLoneSurrogate.
+func WithStrictUTF8() func(*options) {
+	return func(o *options) {
+		o.mode |= scan.StrictUTF8
+	}
+}
+
+// WithSafeIntegers rejects integers outside of the range [-(2^53)+1, (2^53)-1],
+// which cannot be represented exactly by an IEEE 754 double.
+// Next, Skip or Token return ErrUnsafeInteger.
+func WithSafeIntegers() func(*options) {
+	return func(o *options) {
+		o.mode |= scan.SafeIntegers
+	}
+}
+
+// WithIJSON only accepts I-JSON messages (RFC 7493), by combining
+// WithStrictUTF8, WithRejectDuplicateKeys and WithSafeIntegers:
+// strings must be valid UTF-8 without unpaired surrogates,
+// objects may not contain duplicate keys and integers must be within the range [-(2^53)+1, (2^53)-1].
+func WithIJSON() func(*options) {
+	return func(o *options) {
+		WithStrictUTF8()(o)
+		WithRejectDuplicateKeys()(o)
+		WithSafeIntegers()(o)
+	}
+}
+
+// WithNonFiniteNumbers allows the numbers NaN, Infinity and -Infinity, as they are written by Python's json.dumps.
+// Token returns them as parse.Float64Kind, with the corresponding IEEE 754 values.
+func WithNonFiniteNumbers() func(*options) {
+	return func(o *options) {
+		o.mode |= scan.NonFinite
+	}
+}
+
+// WithSkipBOM skips a UTF-8 byte order mark at the start of the input, as written by some Windows tools.
+// Input that is encoded in UTF-16 or UTF-32 needs to be transcoded first, see the transcode package.
+func WithSkipBOM() func(*options) {
+	return func(o *options) {
+		o.mode |= scan.SkipBOM
+	}
+}
+
+// WithExactNumbers returns all decimal numbers from Token as parse.DecimalKind with the exact text of the number,
+// instead of parsing them into an int64 or float64, so that no precision is lost.
+// The text can be converted using token.BigInt, token.BigFloat or token.BigRat.
+// Hexadecimal numbers, NaN and Infinity are still parsed.
+// JSON5 decimals are returned as JSON text, for example ".5" as "0.5", "5." as "5" and "+1" as "1".
+func WithExactNumbers() func(*options) {
+	return func(o *options) {
+		o.exactNumbers = true
+	}
+}
+
+// WithComments allows single line (//) and multi-line (/* */) comments wherever whitespace is allowed.
+// The comments before the current token are returned by Comment.
+func WithComments() func(*options) {
+	return func(o *options) {
+		o.mode |= scan.Comments
+	}
+}
+
+// WithTrailingCommas allows a comma after the last element of an array and after the last member of an object,
+// for example `[1,2,]` and `{"a":1,}`.
+func WithTrailingCommas() func(*options) {
+	return func(o *options) {
+		o.trailingCommas = true
+	}
+}
+
+// WithJSONC parses JSON with comments, as used by configuration files, such as the settings of VS Code and tsconfig.json,
+// by combining WithComments and WithTrailingCommas.
+func WithJSONC() func(*options) {
+	return func(o *options) {
+		WithComments()(o)
+		WithTrailingCommas()(o)
+	}
+}
+
+// WithJSON5 parses JSON5 (https://spec.json5.org) instead of JSON.
+// JSON5 allows comments, which are returned by Comment, single quoted strings, identifiers as object keys, trailing commas,
+// hexadecimal integers, leading and trailing decimal points, a leading '+', Infinity and NaN.
+// Identifiers are returned as strings, and Infinity, NaN and hexadecimal integers that do not fit into an int64 as floats.
+// WithStrictUTF8 and WithSafeIntegers have no effect on JSON5 strings and numbers.
+func WithJSON5() func(*options) {
+	return func(o *options) {
+		o.mode |= scan.JSON5
+		WithTrailingCommas()(o)
+	}
+}
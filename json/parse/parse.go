@@ -28,6 +28,43 @@ type Parser interface {
 
 	// Init restarts the parser with a new byte buffer, without allocating a new parser.
 	Init([]byte)
+	// InitReader restarts the parser with a reader, without allocating a new parser.
+	// The reader is read into a sliding window buffer, that is reused on the next call to InitReader.
+	// Byte slices returned by Token are only valid until the next call to Next or Skip.
+	InitReader(io.Reader)
+	// Feed appends a chunk of input to the parser.
+	// Until Close is called, Next returns ErrNeedMoreInput instead of an error, when a token is cut off at the end of the input.
+	// Calling Next again, after feeding more input, resumes exactly where it stopped.
+	// Byte slices returned by Token are only valid until the next call to Next or Skip.
+	Feed([]byte)
+	// Close signals that no more input will be fed to the parser.
+	Close()
+	// NextDocument moves the parser to the start of the next document, when the input contains multiple documents, see WithLines, WithSequence and WithConcatenated.
+	// Whatever is left of the current document is skipped.
+	// It returns io.EOF if there are no more documents.
+	// Calling Skip at the start of a document skips the whole document.
+	// The first call to Next implicitly moves to the first document.
+	NextDocument() error
+	// RawValue skips over the current value and returns the exact bytes of the input that it spans.
+	// The current value is the value that Next just returned a ValueHint or EnterHint for,
+	// or the value of the field that Next just returned a FieldHint for.
+	// The returned bytes are only valid until the next call to Next, Skip or RawValue.
+	RawValue() ([]byte, error)
+	// Offset returns the offset in bytes of the current token from the start of the input.
+	Offset() int
+	// Position returns the offset, line and column of the current token.
+	// The line and column are only calculated when Position is called.
+	Position() scan.Position
+	// Comment returns the comments before the current token, when comments are allowed, see WithComments.
+	// The current token is the token that Next just returned a hint for.
+	// Comments before commas and colons are not returned.
+	// The returned bytes include the comment delimiters and are only valid until the next call to Next or Skip.
+	Comment() []byte
+	// Uint returns the current value as an unsigned integer, if it is an integer between 0 and math.MaxUint64.
+	// Integers larger than math.MaxInt64 are returned by Token as a parse.DecimalKind,
+	// but are parsed into a uint64 without a round trip through their text.
+	// It returns ErrNotUint for any other value.
+	Uint() (uint64, error)
 	Reset()
 
 	jsonschema.JSONSchemaAble
@@ -37,18 +74,66 @@ type parser struct {
 	// state
 	state state
 	stack []state
+	// skipping is true if Skip needed more input before it reached the skipDepth.
+	skipping  bool
+	skipDepth int
+	// rawing is true if RawValue needed more input before it reached the end of the value.
+	rawing bool
+	// record is the number of the current document, starting at 1.
+	record int
+	// blank is true if the current record only contains whitespace.
+	blank bool
+	// prefixed is true if the input before the first record separator of a JSON text sequence has been skipped.
+	prefixed bool
+	// err is the error of the current document, if it is malformed.
+	err error
 
 	// initialized via options
-	tokenizer token.Tokenizer
+	tokenizer          token.Tokenizer
+	documents          documents
+	skipBlankRecords   bool
+	continueAfterError bool
+	maxDepth           int
+	maxMembers         int
+	maxElements        int
+	// count is the number of members or elements of the current object or array, if they are limited.
+	count int
+	// counts is the stack of counts of the parent objects and arrays.
+	counts []int
+	// rejectDuplicateKeys is true if keys are added to a key set for each object, see WithRejectDuplicateKeys.
+	rejectDuplicateKeys bool
+	keys                keySets
+	// trailingCommas is true if a comma is allowed after the last element of an array or the last member of an object.
+	trailingCommas bool
 }
 
 func NewParser(opts ...Option) Parser {
+	options := newOptions(opts...)
 	p := &parser{
-		state: startState,
-		stack: make([]state, 0, 10),
+		state:              startState,
+		stack:              make([]state, 0, 10),
+		documents:          options.documents,
+		skipBlankRecords:   options.skipBlankRecords,
+		continueAfterError: options.continueAfterError,
+		maxDepth:           options.maxDepth,
+		maxMembers:         options.maxMembers,
+		maxElements:        options.maxElements,
+
+		rejectDuplicateKeys: options.rejectDuplicateKeys,
+		keys:                newKeySets(),
+		trailingCommas:      options.trailingCommas,
 	}
-	options := newOptions(opts...)
 	p.tokenizer = token.NewTokenizerWithCustomAllocator(options.buf, options.alloc)
+	p.tokenizer.Limit(options.limits)
+	p.tokenizer.SetMode(options.mode)
+	p.tokenizer.SetExactNumbers(options.exactNumbers)
+	return p
+}
+
+// NewReaderParser returns a parser that reads from the reader, instead of parsing a complete byte buffer.
+func NewReaderParser(r io.Reader, opts ...Option) Parser {
+	p := NewParser(opts...)
+	p.InitReader(r)
 	return p
 }
 
@@ -56,6 +141,22 @@ func (p *parser) Init(buf []byte) {
 	// Reset the tokenizer with the new buffer.
 	p.tokenizer.Init(buf)
 	p.Reset()
+	p.resetDocuments()
+}
+
+func (p *parser) InitReader(r io.Reader) {
+	// Reset the tokenizer with the new reader.
+	p.tokenizer.InitReader(r)
+	p.Reset()
+	p.resetDocuments()
+}
+
+func (p *parser) Feed(buf []byte) {
+	p.tokenizer.Feed(buf)
+}
+
+func (p *parser) Close() {
+	p.tokenizer.Close()
 }
 
 func (p *parser) Reset() {
@@ -64,6 +165,18 @@ func (p *parser) Reset() {
 	// Shrink the stack's length, but keep it's capacity,
 	// so we can reuse it on the next parse.
 	p.stack = p.stack[:0]
+	p.count = 0
+	p.counts = p.counts[:0]
+	p.keys.reset()
+	p.skipping = false
+	p.rawing = false
+}
+
+func (p *parser) resetDocuments() {
+	p.record = 0
+	p.blank = false
+	p.prefixed = false
+	p.err = nil
 }
 
 func (p *parser) nextToken() (scan.Kind, error) {
@@ -72,7 +185,7 @@ func (p *parser) nextToken() (scan.Kind, error) {
 		return scanKind, nil
 	}
 	if err == io.EOF {
-		return scanKind, io.ErrShortBuffer
+		return scanKind, p.syntaxError(scan.ErrUnexpectedEndOfInput, scan.UnknownKind, nil)
 	}
 	return scanKind, err
 }
@@ -85,14 +198,20 @@ func (p *parser) firstToken() (scan.Kind, error) {
 	return scanKind, err
 }
 
-func (p *parser) assertValue(scanKind scan.Kind) (parse.Hint, error) {
+// syntaxError wraps the error in a *scan.SyntaxError, with the position of the current token.
+func (p *parser) syntaxError(err error, found scan.Kind, expected []scan.Kind) error {
+	return p.tokenizer.SyntaxError(err, found, expected)
+}
+
+// assertValue returns the hint for the value, or a syntax error with the kinds of tokens that were expected instead.
+func (p *parser) assertValue(scanKind scan.Kind, expected []scan.Kind) (parse.Hint, error) {
 	switch scanKind {
 	case scan.NullKind, scan.FalseKind, scan.TrueKind, scan.NumberKind, scan.StringKind:
 		return parse.ValueHint, nil
 	case scan.ArrayOpenKind, scan.ObjectOpenKind:
 		return parse.EnterHint, nil
 	}
-	return parse.UnknownHint, errExpectedValue
+	return parse.UnknownHint, p.syntaxError(scan.ErrExpectedValue, scanKind, expected)
 }
 
 func (p *parser) nextStart() (parse.Hint, error) {
@@ -106,7 +225,7 @@ func (p *parser) nextStart() (parse.Hint, error) {
 	if err != nil {
 		return parse.UnknownHint, err
 	}
-	hint, err := p.assertValue(scanKind)
+	hint, err := p.assertValue(scanKind, scan.ExpectedValue)
 	if err != nil {
 		return parse.UnknownHint, err
 	}
@@ -114,10 +233,14 @@ func (p *parser) nextStart() (parse.Hint, error) {
 		switch scanKind {
 		case scan.ArrayOpenKind:
 			p.state = arrayOpenState
-			p.down(arrayOpenState)
+			if err := p.down(arrayOpenState); err != nil {
+				return parse.UnknownHint, err
+			}
 		case scan.ObjectOpenKind:
 			p.state = objectOpenState
-			p.down(objectOpenState)
+			if err := p.down(objectOpenState); err != nil {
+				return parse.UnknownHint, err
+			}
 		default:
 			panic("unreachable")
 		}
@@ -127,25 +250,43 @@ func (p *parser) nextStart() (parse.Hint, error) {
 	return hint, nil
 }
 
-func (p *parser) maybeDown(scanKind scan.Kind) {
+func (p *parser) maybeDown(scanKind scan.Kind) error {
 	switch scanKind {
 	case scan.ArrayOpenKind:
-		p.down(arrayOpenState)
+		return p.down(arrayOpenState)
 	case scan.ObjectOpenKind:
-		p.down(objectOpenState)
+		return p.down(objectOpenState)
 	}
+	return nil
 }
 
-func (p *parser) nextValue() (parse.Hint, error) {
+// nextValue parses the next value and only then moves to the next state,
+// so that the current state is kept if more input is needed.
+func (p *parser) nextValue(next state) (parse.Hint, error) {
 	scanKind, err := p.nextToken()
 	if err != nil {
 		return parse.UnknownHint, err
 	}
-	hint, err := p.assertValue(scanKind)
+	if next == arrayElementState && p.trailingCommas && scanKind == scan.ArrayCloseKind {
+		// The comma was a trailing comma.
+		if err := p.up(); err != nil {
+			return parse.UnknownHint, err
+		}
+		return parse.LeaveHint, nil
+	}
+	hint, err := p.assertValue(scanKind, scan.ExpectedValue)
 	if err != nil {
 		return hint, err
 	}
-	p.maybeDown(scanKind)
+	if next == arrayElementState {
+		if err := p.countElement(scanKind); err != nil {
+			return parse.UnknownHint, err
+		}
+	}
+	p.state = next
+	if err := p.maybeDown(scanKind); err != nil {
+		return parse.UnknownHint, err
+	}
 	return hint, nil
 }
 
@@ -160,12 +301,17 @@ func (p *parser) firstArrayElement() (parse.Hint, error) {
 		}
 		return parse.LeaveHint, nil
 	}
-	hint, err := p.assertValue(scanKind)
+	hint, err := p.assertValue(scanKind, scan.ExpectedValueOrCloseBracket)
 	if err != nil {
 		return parse.UnknownHint, err
 	}
+	if err := p.countElement(scanKind); err != nil {
+		return parse.UnknownHint, err
+	}
 	p.state = arrayElementState
-	p.maybeDown(scanKind)
+	if err := p.maybeDown(scanKind); err != nil {
+		return parse.UnknownHint, err
+	}
 	return hint, nil
 }
 
@@ -181,9 +327,10 @@ func (p *parser) nextArrayElement() (parse.Hint, error) {
 		return parse.LeaveHint, nil
 	}
 	if scanKind == scan.CommaKind {
-		return p.nextValue()
+		p.state = arrayCommaState
+		return p.nextValue(arrayElementState)
 	}
-	return parse.UnknownHint, errExpectedCommaOrCloseBracket
+	return parse.UnknownHint, p.syntaxError(scan.ErrExpectedCommaOrCloseBracket, scanKind, scan.ExpectedCommaOrCloseBracket)
 }
 
 func (p *parser) firstObjectKey() (parse.Hint, error) {
@@ -197,11 +344,16 @@ func (p *parser) firstObjectKey() (parse.Hint, error) {
 		}
 		return parse.LeaveHint, nil
 	}
-	if scanKind == scan.StringKind {
+	// In JSON5 mode keywords, such as null, are identifiers when they are used as an object key.
+	scanKind = p.tokenizer.Key(len(p.stack))
+	if isKey(scanKind) {
+		if err := p.objectKey(); err != nil {
+			return parse.UnknownHint, err
+		}
 		p.state = objectValueState
 		return parse.FieldHint, nil
 	}
-	return parse.UnknownHint, errExpectedStringOrCloseCurly
+	return parse.UnknownHint, p.syntaxError(scan.ErrExpectedStringOrCloseCurly, scanKind, scan.ExpectedStringOrCloseCurly)
 }
 
 func (p *parser) nextObjectKey() (parse.Hint, error) {
@@ -216,18 +368,40 @@ func (p *parser) nextObjectKey() (parse.Hint, error) {
 		return parse.LeaveHint, nil
 	}
 	if scanKind == scan.CommaKind {
-		nextScanKind, err := p.nextToken()
-		if err != nil {
+		p.state = objectCommaState
+		return p.nextKey()
+	}
+	return parse.UnknownHint, p.syntaxError(scan.ErrExpectedCommaOrCloseCurly, scanKind, scan.ExpectedCommaOrCloseCurly)
+}
+
+func (p *parser) nextKey() (parse.Hint, error) {
+	scanKind, err := p.nextToken()
+	if err != nil {
+		return parse.UnknownHint, err
+	}
+	// In JSON5 mode keywords, such as null, are identifiers when they are used as an object key.
+	scanKind = p.tokenizer.Key(len(p.stack))
+	if isKey(scanKind) {
+		if err := p.objectKey(); err != nil {
 			return parse.UnknownHint, err
 		}
-		if nextScanKind == scan.StringKind {
-			p.state = objectValueState
-			return parse.FieldHint, nil
-		} else {
-			return parse.UnknownHint, errExpectedCommaOrCloseBracket
+		p.state = objectValueState
+		return parse.FieldHint, nil
+	}
+	if p.trailingCommas && scanKind == scan.ObjectCloseKind {
+		// The comma was a trailing comma.
+		if err := p.up(); err != nil {
+			return parse.UnknownHint, err
 		}
+		return parse.LeaveHint, nil
 	}
-	return parse.UnknownHint, errExpectedCommaOrCloseBracket
+	return parse.UnknownHint, p.syntaxError(scan.ErrExpectedString, scanKind, scan.ExpectedString)
+}
+
+// isKey returns true if the token can be an object key.
+// Identifiers are only scanned in JSON5 mode.
+func isKey(scanKind scan.Kind) bool {
+	return scanKind == scan.StringKind || scanKind == scan.IdentifierKind
 }
 
 func (p *parser) nextObjectValue() (parse.Hint, error) {
@@ -236,24 +410,46 @@ func (p *parser) nextObjectValue() (parse.Hint, error) {
 		return parse.UnknownHint, err
 	}
 	if scanKind != scan.ColonKind {
-		return parse.UnknownHint, errExpectedColon
+		return parse.UnknownHint, p.syntaxError(scan.ErrExpectedColon, scanKind, scan.ExpectedColon)
 	}
-	p.state = objectKeyState
-	return p.nextValue()
+	p.state = objectColonState
+	return p.nextValue(objectKeyState)
 }
 
 func (p *parser) eof() error {
-	if _, err := p.tokenizer.Next(); err != nil {
+	if p.documents == concatenatedDocuments {
+		// The next value is the start of the next document.
+		return io.EOF
+	}
+	scanKind, err := p.tokenizer.Next()
+	if err != nil {
 		if err == io.EOF {
 			return io.EOF
 		}
 		return err
 	}
 	// There is still bytes left in the buffer, but the object or array is closed.
-	return io.ErrShortBuffer
+	return p.syntaxError(scan.ErrExpectedEndOfInput, scanKind, nil)
 }
 
 func (p *parser) Next() (parse.Hint, error) {
+	// Calling Next abandons a Skip or RawValue that needed more input.
+	p.skipping = false
+	if p.rawing {
+		p.rawing = false
+		p.tokenizer.Unmark()
+	}
+	if p.documents == singleDocument {
+		return p.next()
+	}
+	if err := p.startDocument(); err != nil {
+		return parse.UnknownHint, err
+	}
+	hint, err := p.next()
+	return hint, p.recordError(err)
+}
+
+func (p *parser) next() (parse.Hint, error) {
 	switch p.state {
 	case startState:
 		return p.nextStart()
@@ -264,12 +460,18 @@ func (p *parser) Next() (parse.Hint, error) {
 		return p.firstArrayElement()
 	case arrayElementState:
 		return p.nextArrayElement()
+	case arrayCommaState:
+		return p.nextValue(arrayElementState)
 	case objectOpenState:
 		return p.firstObjectKey()
 	case objectKeyState:
 		return p.nextObjectKey()
+	case objectCommaState:
+		return p.nextKey()
 	case objectValueState:
 		return p.nextObjectValue()
+	case objectColonState:
+		return p.nextValue(objectKeyState)
 	case endState:
 		return parse.UnknownHint, p.eof()
 	default:
@@ -281,6 +483,22 @@ func (p *parser) Token() (parse.Kind, []byte, error) {
 	return p.tokenizer.Token()
 }
 
+func (p *parser) Offset() int {
+	return p.tokenizer.Offset()
+}
+
+func (p *parser) Position() scan.Position {
+	return p.tokenizer.Position()
+}
+
+func (p *parser) Comment() []byte {
+	return p.tokenizer.Comment()
+}
+
+func (p *parser) Uint() (uint64, error) {
+	return p.tokenizer.Uint()
+}
+
 func (p *parser) JSONSchemaType() jsonschema.JSONSchemaType {
 	switch p.state {
 	case arrayOpenState:
@@ -292,36 +510,42 @@ func (p *parser) JSONSchemaType() jsonschema.JSONSchemaType {
 }
 
 func (p *parser) Skip() error {
+	if p.documents == singleDocument {
+		return p.skip()
+	}
+	if err := p.startDocument(); err != nil {
+		return err
+	}
+	if p.state == startState {
+		// Skip the whole document.
+		return p.recordError(p.skipDocument())
+	}
+	return p.recordError(p.skip())
+}
+
+func (p *parser) skip() error {
+	if p.skipping {
+		// The previous call to Skip needed more input, so continue where it stopped.
+		return p.skipUntil(p.skipDepth)
+	}
 	switch p.state {
-	case arrayOpenState, arrayElementState:
+	case arrayOpenState, arrayElementState, arrayCommaState:
 		// '[' has been parsed or
 		// '['"e1",...,"en" has been parsed.
 		// call Next until ']' is parsed,
 		// which will result in the stack being popped,
 		// which will result in the stack size being smaller.
-		currentStackSize := len(p.stack)
-		for len(p.stack) >= currentStackSize {
-			_, err := p.Next()
-			if err != nil {
-				return err
-			}
-		}
-	case objectOpenState, objectKeyState:
+		return p.skipUntil(len(p.stack) - 1)
+	case objectOpenState, objectKeyState, objectCommaState:
 		// '{' has been parsed or
 		// '{'"k1":"v1",...,"kn":"vn" has been parsed.
 		// call Next until '}' is parsed,
 		// which will result in the stack being popped,
 		// which will result in the stack size being smaller.
+		return p.skipUntil(len(p.stack) - 1)
+	case objectValueState, objectColonState:
 		currentStackSize := len(p.stack)
-		for len(p.stack) >= currentStackSize {
-			_, err := p.Next()
-			if err != nil {
-				return err
-			}
-		}
-	case objectValueState:
-		currentStackSize := len(p.stack)
-		_, err := p.Next()
+		_, err := p.next()
 		if err != nil {
 			return err
 		}
@@ -329,31 +553,57 @@ func (p *parser) Skip() error {
 		// then keep on parsing until we reach our current level.
 		// If Next parsed a string, number, boolean or null,
 		// then the level would be the same.
-		for len(p.stack) > currentStackSize {
-			_, err := p.Next()
-			if err != nil {
-				return err
-			}
-		}
+		return p.skipUntil(currentStackSize)
 	default:
-		_, err := p.Next()
+		_, err := p.next()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipUntil calls next until the stack has been popped to the given depth.
+// If more input is needed, then the depth is remembered,
+// so that the next call to Skip can continue skipping.
+func (p *parser) skipUntil(depth int) error {
+	for len(p.stack) > depth {
+		_, err := p.next()
 		if err != nil {
+			if err == ErrNeedMoreInput {
+				p.skipping = true
+				p.skipDepth = depth
+			}
 			return err
 		}
 	}
+	p.skipping = false
 	return nil
 }
 
-func (p *parser) down(state state) {
+func (p *parser) down(state state) error {
+	if p.maxDepth > 0 && len(p.stack) >= p.maxDepth {
+		// Do not grow the stack, since the input might be hostile.
+		return p.syntaxError(ErrMaxDepthExceeded, p.tokenizer.Kind(), nil)
+	}
 	// Append the current state to the stack.
 	p.stack = append(p.stack, p.state)
+	if p.counting() {
+		p.counts = append(p.counts, p.count)
+		p.count = 0
+	}
+	if p.rejectDuplicateKeys {
+		// Every level gets a key set, even arrays, so that up does not need to know what kind of level it is leaving.
+		p.keys.push()
+	}
 	// Create a new state.
 	p.state = state
+	return nil
 }
 
 func (p *parser) up() error {
 	if len(p.stack) == 0 {
-		return errUnexpectedClose
+		return p.syntaxError(errUnexpectedClose, scan.UnknownKind, nil)
 	}
 	top := len(p.stack) - 1
 	// Set the current state to the state on top of the stack.
@@ -362,8 +612,62 @@ func (p *parser) up() error {
 	// but do it in a way that keeps the capacity,
 	// so we can reuse it the next time Down is called.
 	p.stack = p.stack[:top]
+	if p.counting() {
+		p.count = p.counts[top]
+		p.counts = p.counts[:top]
+	}
+	if p.rejectDuplicateKeys {
+		p.keys.pop()
+	}
 	if len(p.stack) == 0 {
 		p.state = endState
 	}
 	return nil
 }
+
+// counting returns true if the number of members of objects or elements of arrays is limited.
+func (p *parser) counting() bool {
+	return p.maxMembers > 0 || p.maxElements > 0
+}
+
+// objectKey checks the key of the next member of the current object.
+func (p *parser) objectKey() error {
+	if err := p.countMember(); err != nil {
+		return err
+	}
+	if !p.rejectDuplicateKeys {
+		return nil
+	}
+	_, key, err := p.tokenizer.Token()
+	if err != nil {
+		return err
+	}
+	if !p.keys.top().add(key) {
+		return p.syntaxError(&DuplicateKeyError{Key: string(key)}, p.tokenizer.Kind(), nil)
+	}
+	return nil
+}
+
+// countMember counts the fields of the current object and returns an error if there are too many.
+func (p *parser) countMember() error {
+	if p.maxMembers == 0 {
+		return nil
+	}
+	p.count++
+	if p.count > p.maxMembers {
+		return p.syntaxError(ErrTooManyMembers, scan.StringKind, nil)
+	}
+	return nil
+}
+
+// countElement counts the elements of the current array and returns an error if there are too many.
+func (p *parser) countElement(scanKind scan.Kind) error {
+	if p.maxElements == 0 {
+		return nil
+	}
+	p.count++
+	if p.count > p.maxElements {
+		return p.syntaxError(ErrTooManyElements, scanKind, nil)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/scan"
+	"github.com/katydid/parser-go/parse"
+)
+
+func TestPosition(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte("{\n\"a\": [true,\n null]}"))
+	want := []struct {
+		hint parse.Hint
+		pos  scan.Position
+	}{
+		{parse.EnterHint, scan.Position{Offset: 0, Line: 1, Column: 1}},
+		{parse.FieldHint, scan.Position{Offset: 2, Line: 2, Column: 1}},
+		{parse.EnterHint, scan.Position{Offset: 7, Line: 2, Column: 6}},
+		{parse.ValueHint, scan.Position{Offset: 8, Line: 2, Column: 7}},
+		{parse.ValueHint, scan.Position{Offset: 15, Line: 3, Column: 2}},
+		{parse.LeaveHint, scan.Position{Offset: 19, Line: 3, Column: 6}},
+		{parse.LeaveHint, scan.Position{Offset: 20, Line: 3, Column: 7}},
+	}
+	for _, w := range want {
+		hint, err := p.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hint != w.hint {
+			t.Fatalf("want hint %v, but got %v", w.hint, hint)
+		}
+		if got := p.Position(); got != w.pos {
+			t.Fatalf("want position %v, but got %v", w.pos, got)
+		}
+		if got := p.Offset(); got != w.pos.Offset {
+			t.Fatalf("want offset %d, but got %d", w.pos.Offset, got)
+		}
+	}
+}
+
+func TestPositionLines(t *testing.T) {
+	p := NewParser(WithLines())
+	p.Init([]byte("1\n 2\n"))
+	expectNextDocument(t, p)
+	if _, err := p.Next(); err != nil {
+		t.Fatal(err)
+	}
+	expectNextDocument(t, p)
+	if _, err := p.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.Position(), (scan.Position{Offset: 3, Line: 2, Column: 2}); got != want {
+		t.Fatalf("want position %v, but got %v", want, got)
+	}
+}
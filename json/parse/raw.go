@@ -0,0 +1,69 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"errors"
+
+	"github.com/katydid/parser-go-json/json/scan"
+)
+
+var errNoValue = errors.New("no current value")
+
+func (p *parser) RawValue() ([]byte, error) {
+	if p.documents == singleDocument {
+		return p.rawValue()
+	}
+	if err := p.startDocument(); err != nil {
+		return nil, err
+	}
+	raw, err := p.rawValue()
+	return raw, p.recordError(err)
+}
+
+func (p *parser) rawValue() ([]byte, error) {
+	if !p.rawing {
+		switch p.state {
+		case startState, objectValueState, objectColonState:
+			// The value has not been parsed yet.
+			if _, err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+		switch p.tokenizer.Kind() {
+		case scan.NullKind, scan.FalseKind, scan.TrueKind, scan.NumberKind, scan.StringKind:
+			p.tokenizer.Mark()
+			return p.tokenizer.Raw()
+		case scan.ArrayOpenKind, scan.ObjectOpenKind:
+			// The array or object has just been entered, so skip until it is closed.
+			p.tokenizer.Mark()
+			p.rawing = true
+			p.skipping = true
+			p.skipDepth = len(p.stack) - 1
+		default:
+			return nil, errNoValue
+		}
+	}
+	// If more input is needed, the next call to RawValue continues skipping.
+	if err := p.skipUntil(p.skipDepth); err != nil {
+		if err != ErrNeedMoreInput {
+			p.rawing = false
+			p.tokenizer.Unmark()
+		}
+		return nil, err
+	}
+	p.rawing = false
+	return p.tokenizer.Raw()
+}
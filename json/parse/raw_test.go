@@ -0,0 +1,141 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+	"github.com/katydid/parser-go/pool"
+)
+
+func expectRaw(t *testing.T, p Parser, want string) {
+	t.Helper()
+	got, err := p.RawValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Fatalf("want raw value %s, but got %s", want, got)
+	}
+}
+
+const rawInput = `{"num": 1.50e+10, "payload" : { "a" : [1, "b"] }, "arr": [true, {}, [ null ]], "s":"A"}`
+
+func TestRawValueOfField(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(rawInput))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expectRaw(t, p, `1.50e+10`)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "payload")
+	expectRaw(t, p, `{ "a" : [1, "b"] }`)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "arr")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expectRaw(t, p, `true`)
+	expect.Hint(t, p, parse.EnterHint)
+	expectRaw(t, p, `{}`)
+	expect.Hint(t, p, parse.EnterHint)
+	expectRaw(t, p, `[ null ]`)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "s")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "A")
+	// The string has already been tokenized, but the raw value is still the escaped input.
+	expectRaw(t, p, `"A"`)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestRawValueOfDocument(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(" \n" + rawInput + " \n"))
+	expectRaw(t, p, rawInput)
+	expect.EOF(t, p)
+}
+
+func TestRawValueAfterLeave(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(`[[]]`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	if _, err := p.RawValue(); err != errNoValue {
+		t.Fatalf("expected no value, but got %v", err)
+	}
+}
+
+func TestRawValueRandomValues(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	p := NewParser()
+	for _, value := range values {
+		t.Run(testrun.Name(value), func(t *testing.T) {
+			p.Init(value)
+			expectRaw(t, p, string(bytes.TrimSpace(value)))
+			expect.EOF(t, p)
+		})
+	}
+}
+
+func TestRawValueReader(t *testing.T) {
+	// The payload does not fit into the initial window, so the window needs to keep the marked bytes.
+	payload := `{"a":[` + strings.Repeat(`"abcdefghijklmnop", `, 500) + `1]}`
+	input := `{"payload":` + payload + `,"b":2}`
+	p := NewReaderParser(iotest.OneByteReader(strings.NewReader(input)))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expectRaw(t, p, payload)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "b")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 2)
+}
+
+func TestRawValueFeed(t *testing.T) {
+	p := NewParser()
+	p.Feed([]byte(`{"payload":{"a":[1,`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	if _, err := p.RawValue(); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	p.Feed([]byte(`2]},"b":3}`))
+	expectRaw(t, p, `{"a":[1,2]}`)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "b")
+	p.Close()
+}
+
+func TestRawValueNotASingleAllocAfterWarmUp(t *testing.T) {
+	pool := pool.New()
+	p := NewParser(WithAllocator(pool.Alloc))
+	testrun.NotASingleAllocAfterWarmUp(t, pool, func(bs []byte) {
+		p.Init(bs)
+		if _, err := p.RawValue(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
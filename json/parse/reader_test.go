@@ -0,0 +1,97 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+	"github.com/katydid/parser-go/pool"
+)
+
+func TestReaderParseExample(t *testing.T) {
+	s := `{"num":3.14,"arr":[null,false,true,1,2],"obj":{"k":"v","a":[1,2,3],"b":1,"c":2}}`
+	p := NewReaderParser(iotest.OneByteReader(strings.NewReader(s)))
+	expect.Hint(t, p, parse.EnterHint)
+
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "num")
+
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Float(t, p, 3.14)
+
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "arr")
+
+	expect.Hint(t, p, parse.EnterHint)
+
+	expect.Hint(t, p, parse.ValueHint) // null
+
+	expect.Hint(t, p, parse.ValueHint)
+	expect.False(t, p)
+
+	expect.NoErr(t, p.Skip) // skip true,1,2]
+
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "obj")
+
+	expect.NoErr(t, p.Skip)
+
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestReaderParseInvalidArrayWithSuffix(t *testing.T) {
+	s := `[1] [`
+	p := NewReaderParser(iotest.OneByteReader(strings.NewReader(s)))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Err(t, p.Next)
+}
+
+func TestReaderRandomlyParseRandomValues(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	for _, value := range values {
+		name := testrun.Name(value)
+		t.Run(name, func(t *testing.T) {
+			p := NewReaderParser(iotest.OneByteReader(bytes.NewReader(value)))
+			if err := randWalk(r, p); err != nil {
+				t.Fatalf("expected EOF, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestReaderNotASingleAllocAfterWarmUp(t *testing.T) {
+	pool := pool.New()
+	r := bytes.NewReader(nil)
+	p := NewReaderParser(r, WithAllocator(pool.Alloc))
+	testrun.NotASingleAllocAfterWarmUp(t, pool, func(bs []byte) {
+		r.Reset(bs)
+		p.InitReader(r)
+		if err := walk(p); err != nil {
+			t.Fatalf("expected EOF, but got %v", err)
+		}
+	})
+}
@@ -0,0 +1,187 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func TestSequenceExample(t *testing.T) {
+	p := NewParser(WithSequence())
+	p.Init([]byte("\x1e{\"a\":1}\n\x1e\x1e[true]\n\x1e\"s\"\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.True(t, p)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "s")
+	expect.EOF(t, p)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestSequenceValueSpanningLines(t *testing.T) {
+	p := NewParser(WithSequence())
+	p.Init([]byte("\x1e[1,\n2]\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 2)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestSequenceMissingRecordSeparator(t *testing.T) {
+	p := NewParser(WithSequence())
+	p.Init([]byte("1\n\x1e2\n"))
+	expectRecordError(t, p.NextDocument(), 0)
+}
+
+func TestSequenceContinueAfterTruncatedRecord(t *testing.T) {
+	p := NewParser(WithSequence(), WithContinueAfterError())
+	p.Init([]byte("\x1e{\"a\":\x1e{\"b\":2}\n"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	_, err := p.Next()
+	expectRecordError(t, err, 1)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "b")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 2)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestSequenceSkipRecord(t *testing.T) {
+	p := NewParser(WithSequence())
+	p.Init([]byte("\x1e{\"a\":[1,{\"b\":2}]}\n\x1e3\n"))
+	expectNextDocument(t, p)
+	expect.NoErr(t, p.Skip)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 3)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestSequenceFeed(t *testing.T) {
+	p := NewParser(WithSequence())
+	p.Feed([]byte("\x1e[1"))
+	if err := p.NextDocument(); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	p.Feed([]byte("]\n\x1e"))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	p.Feed([]byte("2\n"))
+	p.Close()
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 2)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestConcatenatedExample(t *testing.T) {
+	p := NewParser(WithConcatenated())
+	p.Init([]byte(`{"a":1}{"b":[2]} 3"s"[]`))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "b")
+	// The rest of the document is skipped.
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 3)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	// The whole document is skipped.
+	expect.NoErr(t, p.Skip)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+	expectNoMoreDocuments(t, p)
+}
+
+func TestConcatenatedMalformed(t *testing.T) {
+	p := NewParser(WithConcatenated(), WithContinueAfterError())
+	p.Init([]byte(`{"a":1}{"b"]}{"c":3}`))
+	expectNextDocument(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	_, err := p.Next()
+	expectRecordError(t, err, 2)
+	// There is no framing to recover with.
+	expectRecordError(t, p.NextDocument(), 2)
+}
+
+func TestConcatenatedFeed(t *testing.T) {
+	p := NewParser(WithConcatenated())
+	p.Feed([]byte(`[1]1`))
+	expectNextDocument(t, p)
+	expect.NoErr(t, p.Skip)
+	// The number might continue in the next chunk.
+	if err := p.NextDocument(); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	p.Feed([]byte(`2 {`))
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 12)
+	expect.EOF(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expectNeedMoreInput(t, p)
+	if err := p.NextDocument(); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	p.Feed([]byte(`"a":[1,2]}`))
+	p.Close()
+	expectNoMoreDocuments(t, p)
+}
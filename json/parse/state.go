@@ -25,10 +25,22 @@ const arrayOpenState = state('[')
 
 const arrayElementState = state(',')
 
+// arrayCommaState is for when a ',' in an array has been parsed, but not yet the element that follows it.
+// This allows parsing to resume, after more input has been fed.
+const arrayCommaState = state('a')
+
 const objectOpenState = state('{')
 
 const objectKeyState = state('k')
 
 const objectValueState = state('v')
 
+// objectCommaState is for when a ',' in an object has been parsed, but not yet the key that follows it.
+// This allows parsing to resume, after more input has been fed.
+const objectCommaState = state('c')
+
+// objectColonState is for when a ':' in an object has been parsed, but not yet the value that follows it.
+// This allows parsing to resume, after more input has been fed.
+const objectColonState = state(':')
+
 const endState = state('e')
@@ -0,0 +1,74 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func TestStrictUTF8Token(t *testing.T) {
+	p := NewParser(WithStrictUTF8())
+	p.Init([]byte(`["\u00e9", "\ud800"]`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "\u00e9")
+	expect.Hint(t, p, parse.ValueHint)
+	_, _, err := p.Token()
+	syntaxErr := expectSyntaxError(t, err, ErrLoneSurrogate)
+	if syntaxErr.Offset != 11 {
+		t.Fatalf("want offset 11, but got %d", syntaxErr.Offset)
+	}
+}
+
+func TestStrictUTF8Skip(t *testing.T) {
+	p := NewParser(WithStrictUTF8())
+	p.Init([]byte("{\"\xff\": 1}"))
+	expect.Hint(t, p, parse.EnterHint)
+	// The key is not tokenized, but Next still validates it, when it scans to the end of it.
+	expect.Hint(t, p, parse.FieldHint)
+	_, err := p.Next()
+	expectSyntaxError(t, err, ErrInvalidUTF8)
+
+	p.Init([]byte("[[\"\xff\"]]"))
+	expect.Hint(t, p, parse.EnterHint)
+	if err := p.Skip(); !errors.Is(err, ErrInvalidUTF8) {
+		t.Fatalf("expected %v, but got %v", ErrInvalidUTF8, err)
+	}
+}
+
+func TestWithoutStrictUTF8(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte("[\"\xff\", \"\\ud800\"]"))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "\uFFFD")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "\uFFFD")
+}
+
+func TestStrictUTF8Feed(t *testing.T) {
+	// Multi-byte characters and surrogate pairs are cut in half by feeding one byte at a time.
+	input := []byte(`["\u00e9\ud83d\ude00", ` + "\"\U0001F600\"" + `, {"\u00e9": "\ud83d\ude00"}]`)
+	p := NewParser(WithStrictUTF8())
+	if err := feedWalk(rand.NewRand(), p, input); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package parse
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/scan"
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func expectSyntaxError(t *testing.T, err error, sentinel error) *scan.SyntaxError {
+	t.Helper()
+	if !errors.Is(err, sentinel) {
+		t.Fatalf("expected %v, but got %v", sentinel, err)
+	}
+	var syntaxErr *scan.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected syntax error, but got %v", err)
+	}
+	return syntaxErr
+}
+
+func TestSyntaxErrorExpectedColon(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte("{\n\"a\" \"b\"}"))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	_, err := p.Next()
+	syntaxErr := expectSyntaxError(t, err, scan.ErrExpectedColon)
+	if want := (scan.Position{Offset: 6, Line: 2, Column: 5}); syntaxErr.Position != want {
+		t.Fatalf("want position %v, but got %v", want, syntaxErr.Position)
+	}
+	if syntaxErr.Found != scan.StringKind {
+		t.Fatalf("want found %v, but got %v", scan.StringKind, syntaxErr.Found)
+	}
+	if want := []scan.Kind{scan.ColonKind}; !slices.Equal(syntaxErr.Expected, want) {
+		t.Fatalf("want expected %v, but got %v", want, syntaxErr.Expected)
+	}
+}
+
+func TestSyntaxErrorExpectedCommaOrCloseBracket(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(`[1 2]`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	_, err := p.Next()
+	syntaxErr := expectSyntaxError(t, err, scan.ErrExpectedCommaOrCloseBracket)
+	if syntaxErr.Offset != 3 {
+		t.Fatalf("want offset 3, but got %d", syntaxErr.Offset)
+	}
+	if syntaxErr.Found != scan.NumberKind {
+		t.Fatalf("want found %v, but got %v", scan.NumberKind, syntaxErr.Found)
+	}
+}
+
+func TestSyntaxErrorExpectedCommaOrCloseCurly(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(`{"a":1 "b":2}`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Hint(t, p, parse.ValueHint)
+	_, err := p.Next()
+	syntaxErr := expectSyntaxError(t, err, scan.ErrExpectedCommaOrCloseCurly)
+	if want := []scan.Kind{scan.CommaKind, scan.ObjectCloseKind}; !slices.Equal(syntaxErr.Expected, want) {
+		t.Fatalf("want expected %v, but got %v", want, syntaxErr.Expected)
+	}
+}
+
+func TestSyntaxErrorExpectedString(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(`{"a":1,2:3}`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Hint(t, p, parse.ValueHint)
+	_, err := p.Next()
+	syntaxErr := expectSyntaxError(t, err, scan.ErrExpectedString)
+	if syntaxErr.Found != scan.NumberKind {
+		t.Fatalf("want found %v, but got %v", scan.NumberKind, syntaxErr.Found)
+	}
+}
+
+func TestSyntaxErrorUnexpectedEndOfInput(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(`[1`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	_, err := p.Next()
+	expectSyntaxError(t, err, scan.ErrUnexpectedEndOfInput)
+	if !errors.Is(err, io.ErrShortBuffer) {
+		t.Fatalf("expected short buffer, but got %v", err)
+	}
+}
+
+func TestSyntaxErrorExpectedEndOfInput(t *testing.T) {
+	for _, input := range []string{`1 2`, `[1] ]`} {
+		p := NewParser()
+		p.Init([]byte(input))
+		var err error
+		for err == nil {
+			_, err = p.Next()
+		}
+		syntaxErr := expectSyntaxError(t, err, scan.ErrExpectedEndOfInput)
+		if !errors.Is(err, io.ErrShortBuffer) {
+			t.Fatalf("%s: expected short buffer, but got %v", input, err)
+		}
+		if syntaxErr.Found == scan.UnknownKind {
+			t.Fatalf("%s: want the kind of the trailing token", input)
+		}
+	}
+}
+
+func TestSyntaxErrorSkippedString(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(`["a\x", 1]`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.ValueHint)
+	// The string is not tokenized, but it still needs to be scanned.
+	_, err := p.Next()
+	var syntaxErr *scan.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected syntax error, but got %v", err)
+	}
+	if syntaxErr.Offset != 1 || syntaxErr.Found != scan.StringKind {
+		t.Fatalf("want string at offset 1, but got %v at %d", syntaxErr.Found, syntaxErr.Offset)
+	}
+}
+
+func TestSyntaxErrorInRecord(t *testing.T) {
+	p := NewParser(WithLines())
+	p.Init([]byte("1\n{\"a\":}\n"))
+	expectNextDocument(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	_, err := p.Next()
+	expectRecordError(t, err, 2)
+	syntaxErr := expectSyntaxError(t, err, scan.ErrExpectedValue)
+	if want := (scan.Position{Offset: 7, Line: 2, Column: 6}); syntaxErr.Position != want {
+		t.Fatalf("want position %v, but got %v", want, syntaxErr.Position)
+	}
+	// The excerpt does not include the previous record.
+	if want := "{\"a\":}"; syntaxErr.Excerpt != want {
+		t.Fatalf("want excerpt %q, but got %q", want, syntaxErr.Excerpt)
+	}
+}
+
+func TestSyntaxErrorInSequenceRecord(t *testing.T) {
+	p := NewParser(WithSequence())
+	p.Init([]byte("\x1e1\n\x1e{\"a\":\n"))
+	expectNextDocument(t, p)
+	expectNextDocument(t, p)
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	_, err := p.Next()
+	expectRecordError(t, err, 2)
+	syntaxErr := expectSyntaxError(t, err, scan.ErrUnexpectedEndOfInput)
+	if want := "{\"a\":\n"; syntaxErr.Excerpt != want {
+		t.Fatalf("want excerpt %q, but got %q", want, syntaxErr.Excerpt)
+	}
+}
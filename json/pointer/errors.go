@@ -0,0 +1,23 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pointer
+
+import "errors"
+
+// ErrNotFound is returned when the value that the pointer refers to does not exist.
+var ErrNotFound = errors.New("pointer not found")
+
+// ErrInvalidPointer is returned when the pointer is not empty and does not start with '/'.
+var ErrInvalidPointer = errors.New("invalid pointer")
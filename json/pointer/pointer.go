@@ -0,0 +1,172 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package pointer positions a parser on the value that a JSON Pointer (RFC 6901) refers to.
+package pointer
+
+import (
+	"strings"
+
+	"github.com/katydid/parser-go/cast"
+	"github.com/katydid/parser-go/parse"
+)
+
+// Seek calls Next, Skip and Token on the parser, until it is positioned on the value that the pointer refers to,
+// for example "/a/b/0/c" or "" for the whole document.
+// Seek returns the hint of that value:
+//   - parse.ValueHint, in which case Token returns the value.
+//   - parse.EnterHint, in which case Next walks the object or array.
+//
+// Array indexes are matched to the indexes that are produced by tag.WithIndexes,
+// but arrays without indexes are also supported, by counting their elements.
+// The "object" and "array" tags that are produced by tag.WithTags are stepped over.
+// Seek does not allocate.
+func Seek(p parse.Parser, pointer string) (parse.Hint, error) {
+	if len(pointer) > 0 && pointer[0] != '/' {
+		return parse.UnknownHint, ErrInvalidPointer
+	}
+	hint, err := p.Next()
+	if err != nil {
+		return parse.UnknownHint, err
+	}
+	for len(pointer) > 0 {
+		// Remove the leading '/' and cut out the next reference token.
+		pointer = pointer[1:]
+		ref := pointer
+		if i := strings.IndexByte(pointer, '/'); i >= 0 {
+			ref, pointer = pointer[:i], pointer[i:]
+		} else {
+			pointer = ""
+		}
+		if !validEscapes(ref) {
+			return parse.UnknownHint, ErrInvalidPointer
+		}
+		if hint != parse.EnterHint {
+			return parse.UnknownHint, ErrNotFound
+		}
+		hint, err = seekChild(p, ref)
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+	}
+	return hint, nil
+}
+
+// seekChild is called after an EnterHint and positions the parser on the field or element with the reference.
+func seekChild(p parse.Parser, ref string) (parse.Hint, error) {
+	index, isIndex := parseIndex(ref)
+	// count is the number of elements that have been seen in an array without indexes.
+	count := int64(0)
+	for {
+		hint, err := p.Next()
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		switch hint {
+		case parse.LeaveHint:
+			return parse.UnknownHint, ErrNotFound
+		case parse.FieldHint:
+			kind, token, err := p.Token()
+			if err != nil {
+				return parse.UnknownHint, err
+			}
+			switch kind {
+			case parse.TagKind:
+				// Step over the "object" or "array" tag, into the object or array that it tags.
+				hint, err := p.Next()
+				if err != nil {
+					return parse.UnknownHint, err
+				}
+				if hint != parse.EnterHint {
+					return parse.UnknownHint, ErrNotFound
+				}
+				continue
+			case parse.StringKind:
+				if matchKey(ref, token) {
+					return p.Next()
+				}
+			case parse.Int64Kind:
+				if isIndex && cast.ToInt64(token) == index {
+					return p.Next()
+				}
+			}
+			if err := p.Skip(); err != nil {
+				return parse.UnknownHint, err
+			}
+		case parse.ValueHint, parse.EnterHint:
+			// An element of an array without indexes.
+			if isIndex && count == index {
+				return hint, nil
+			}
+			count++
+			if hint == parse.EnterHint {
+				if err := p.Skip(); err != nil {
+					return parse.UnknownHint, err
+				}
+			}
+		}
+	}
+}
+
+// parseIndex parses an array index, which is "0" or a number without leading zeros.
+func parseIndex(ref string) (int64, bool) {
+	if len(ref) == 0 || len(ref) > 18 || (ref[0] == '0' && len(ref) > 1) {
+		return 0, false
+	}
+	index := int64(0)
+	for i := 0; i < len(ref); i++ {
+		c := ref[i]
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		index = index*10 + int64(c-'0')
+	}
+	return index, true
+}
+
+// validEscapes checks that each '~' is followed by '0' or '1'.
+func validEscapes(ref string) bool {
+	for i := 0; i < len(ref); i++ {
+		if ref[i] == '~' {
+			if i+1 == len(ref) || (ref[i+1] != '0' && ref[i+1] != '1') {
+				return false
+			}
+			i++
+		}
+	}
+	return true
+}
+
+// matchKey compares the reference token to the key, while unescaping "~1" to '/' and "~0" to '~'.
+func matchKey(ref string, key []byte) bool {
+	k := 0
+	for i := 0; i < len(ref); i++ {
+		c := ref[i]
+		if c == '~' {
+			switch ref[i+1] {
+			case '0':
+				c = '~'
+				i++
+			case '1':
+				c = '/'
+				i++
+			}
+		}
+		if k >= len(key) || key[k] != c {
+			return false
+		}
+		k++
+	}
+	return k == len(key)
+}
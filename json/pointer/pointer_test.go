@@ -0,0 +1,181 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package pointer_test
+
+import (
+	"testing"
+
+	"github.com/katydid/parser-go-json/json"
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	jsonparse "github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/pointer"
+	"github.com/katydid/parser-go/cast"
+	"github.com/katydid/parser-go/parse"
+	"github.com/katydid/parser-go/pool"
+)
+
+// The example from RFC 6901 section 5, with an extra nested object.
+var rfcExample = `{
+	"foo": ["bar", "baz"],
+	"": 0,
+	"a/b": 1,
+	"c%d": 2,
+	"e^f": 3,
+	"g|h": 4,
+	"i\\j": 5,
+	"k\"l": 6,
+	" ": 7,
+	"m~n": 8,
+	"nested": {"list": [{"x": 9}, [10, {"y": 11}]]}
+}`
+
+var rfcInput = []byte(rfcExample)
+
+var rfcCases = []struct {
+	pointer string
+	want    int64
+}{
+	{"/", 0},
+	{"/a~1b", 1},
+	{"/c%d", 2},
+	{"/e^f", 3},
+	{"/g|h", 4},
+	{"/i\\j", 5},
+	{"/k\"l", 6},
+	{"/ ", 7},
+	{"/m~0n", 8},
+	{"/nested/list/0/x", 9},
+	{"/nested/list/1/0", 10},
+	{"/nested/list/1/1/y", 11},
+}
+
+type initParser interface {
+	parse.Parser
+	Init([]byte)
+}
+
+func expectInt(t *testing.T, p initParser, ptr string, want int64) {
+	t.Helper()
+	p.Init(rfcInput)
+	hint, err := pointer.Seek(p, ptr)
+	if err != nil {
+		t.Fatalf("%s: %v", ptr, err)
+	}
+	if hint != parse.ValueHint {
+		t.Fatalf("%s: expected value, but got %v", ptr, hint)
+	}
+	kind, token, err := p.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != parse.Int64Kind || cast.ToInt64(token) != want {
+		t.Fatalf("%s: want %d, but got %v %v", ptr, want, kind, token)
+	}
+}
+
+func parsers() map[string]initParser {
+	return map[string]initParser{
+		"indexes": json.NewParser(),
+		"tags":    json.NewJSONSchemaParser(),
+		"plain":   jsonparse.NewParser(),
+	}
+}
+
+func TestSeekRFCExample(t *testing.T) {
+	for name, p := range parsers() {
+		t.Run(name, func(t *testing.T) {
+			for _, c := range rfcCases {
+				expectInt(t, p, c.pointer, c.want)
+			}
+		})
+	}
+}
+
+func TestSeekString(t *testing.T) {
+	for name, p := range parsers() {
+		t.Run(name, func(t *testing.T) {
+			p.Init(rfcInput)
+			if _, err := pointer.Seek(p, "/foo/1"); err != nil {
+				t.Fatal(err)
+			}
+			kind, token, err := p.Token()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if kind != parse.StringKind || string(token) != "baz" {
+				t.Fatalf("want baz, but got %v %s", kind, token)
+			}
+		})
+	}
+}
+
+func TestSeekContainer(t *testing.T) {
+	for name, p := range parsers() {
+		t.Run(name, func(t *testing.T) {
+			for _, ptr := range []string{"", "/foo", "/nested", "/nested/list/1"} {
+				p.Init(rfcInput)
+				hint, err := pointer.Seek(p, ptr)
+				if err != nil {
+					t.Fatalf("%s: %v", ptr, err)
+				}
+				if hint != parse.EnterHint {
+					t.Fatalf("%s: expected enter, but got %v", ptr, hint)
+				}
+			}
+		})
+	}
+}
+
+func TestSeekNotFound(t *testing.T) {
+	for name, p := range parsers() {
+		t.Run(name, func(t *testing.T) {
+			for _, ptr := range []string{"/bar", "/foo/2", "/foo/-", "/foo/01", "/foo/a", "/a~1b/c", "/nested/list/0/y"} {
+				p.Init(rfcInput)
+				if _, err := pointer.Seek(p, ptr); err != pointer.ErrNotFound {
+					t.Fatalf("%s: expected not found, but got %v", ptr, err)
+				}
+			}
+		})
+	}
+}
+
+func TestSeekInvalidPointer(t *testing.T) {
+	for _, ptr := range []string{"foo", "/m~2n", "/m~"} {
+		p := json.NewParser()
+		p.Init(rfcInput)
+		if _, err := pointer.Seek(p, ptr); err != pointer.ErrInvalidPointer {
+			t.Fatalf("%s: expected invalid pointer, but got %v", ptr, err)
+		}
+	}
+}
+
+func TestSeekNotASingleAllocAfterWarmUp(t *testing.T) {
+	pool := pool.New()
+	p := jsonparse.NewParser(jsonparse.WithAllocator(pool.Alloc))
+	testrun.NotASingleAllocAfterWarmUp(t, pool, func(bs []byte) {
+		p.Init(bs)
+		// Most random values do not contain this field, so Seek needs to skip over the whole value.
+		_, _ = pointer.Seek(p, "/A/0/~0")
+	})
+	indexed := json.NewParser()
+	for _, c := range rfcCases {
+		expectInt(t, indexed, c.pointer, c.want)
+	}
+	for _, c := range rfcCases {
+		if allocs := testing.AllocsPerRun(10, func() { expectInt(t, indexed, c.pointer, c.want) }); allocs != 0 {
+			t.Fatalf("%s: expected no allocations, but got %v", c.pointer, allocs)
+		}
+	}
+}
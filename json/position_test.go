@@ -0,0 +1,56 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package json
+
+import (
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/scan"
+	"github.com/katydid/parser-go/parse"
+)
+
+func TestPositionThroughTagger(t *testing.T) {
+	p := NewJSONSchemaParser()
+	p.Init([]byte("{\"a\":\n [1]}"))
+	want := []struct {
+		hint parse.Hint
+		pos  scan.Position
+	}{
+		// The "object" tag is at the position of the object.
+		{parse.EnterHint, scan.Position{Offset: 0, Line: 1, Column: 1}},
+		{parse.FieldHint, scan.Position{Offset: 0, Line: 1, Column: 1}},
+		{parse.EnterHint, scan.Position{Offset: 0, Line: 1, Column: 1}},
+		{parse.FieldHint, scan.Position{Offset: 1, Line: 1, Column: 2}},
+		// The "array" tag is at the position of the array.
+		{parse.EnterHint, scan.Position{Offset: 7, Line: 2, Column: 2}},
+		{parse.FieldHint, scan.Position{Offset: 7, Line: 2, Column: 2}},
+		{parse.EnterHint, scan.Position{Offset: 7, Line: 2, Column: 2}},
+		// The index is at the position of the element.
+		{parse.FieldHint, scan.Position{Offset: 8, Line: 2, Column: 3}},
+		{parse.ValueHint, scan.Position{Offset: 8, Line: 2, Column: 3}},
+	}
+	for _, w := range want {
+		hint, err := p.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hint != w.hint {
+			t.Fatalf("want hint %v, but got %v", w.hint, hint)
+		}
+		if got := p.Position(); got != w.pos {
+			t.Fatalf("want position %v, but got %v at hint %v", w.pos, got, hint)
+		}
+	}
+}
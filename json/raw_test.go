@@ -0,0 +1,82 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package json
+
+import (
+	"testing"
+
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+func expectRaw(t *testing.T, p Parser, want string) {
+	t.Helper()
+	got, err := p.RawValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Fatalf("want raw value %s, but got %s", want, got)
+	}
+}
+
+func TestRawValueWithIndexes(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(`{"payload": {"a" : [1, 2.50]}, "arr": [{"b":1}, 3], "c": 4}`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "payload")
+	expectRaw(t, p, `{"a" : [1, 2.50]}`)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "arr")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Int(t, p, 0)
+	expectRaw(t, p, `{"b":1}`)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Int(t, p, 1)
+	expect.Hint(t, p, parse.ValueHint)
+	expectRaw(t, p, `3`)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "c")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.Int(t, p, 4)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestRawValueWithTags(t *testing.T) {
+	p := NewJSONSchemaParser()
+	p.Init([]byte(`{"a": [1, 2], "b": {"c":3}}`))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, "object")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "a")
+	expectRaw(t, p, `[1, 2]`)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "b")
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.Tag(t, p, "object")
+	// The value of the "object" tag is the object itself.
+	expectRaw(t, p, `{"c":3}`)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
@@ -0,0 +1,165 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go/parse"
+	"github.com/katydid/parser-go/parse/debug"
+)
+
+// expectSameWalk walks both parsers in lock step,
+// since tokens returned by a parser initialized with a reader are only valid until the next call to Next.
+func expectSameWalk(t *testing.T, want, got Parser) {
+	t.Helper()
+	for {
+		wantHint, wantErr := want.Next()
+		gotHint, gotErr := got.Next()
+		if wantErr != gotErr {
+			t.Fatalf("want error %v, but got %v", wantErr, gotErr)
+		}
+		if wantErr != nil {
+			return
+		}
+		if wantHint != gotHint {
+			t.Fatalf("want hint %v, but got %v", wantHint, gotHint)
+		}
+		if wantHint == parse.EnterHint || wantHint == parse.LeaveHint {
+			continue
+		}
+		wantKind, wantToken, wantErr := want.Token()
+		gotKind, gotToken, gotErr := got.Token()
+		if wantErr != gotErr {
+			t.Fatalf("want token error %v, but got %v", wantErr, gotErr)
+		}
+		if wantKind != gotKind {
+			t.Fatalf("want kind %v, but got %v", wantKind, gotKind)
+		}
+		if !bytes.Equal(wantToken, gotToken) {
+			t.Fatalf("want token %v, but got %v", wantToken, gotToken)
+		}
+	}
+}
+
+func TestInitReaderSameAsInit(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	want := NewParser()
+	got := NewParser()
+	for _, value := range values {
+		t.Run(testrun.Name(value), func(t *testing.T) {
+			want.Init(value)
+			got.InitReader(iotest.OneByteReader(bytes.NewReader(value)))
+			expectSameWalk(t, want, got)
+		})
+	}
+}
+
+func expectSameParse(t *testing.T, want, got Parser) {
+	t.Helper()
+	wantNodes, wantErr := debug.Parse(want)
+	gotNodes, gotErr := debug.Parse(got)
+	if wantErr != nil || gotErr != nil {
+		t.Fatalf("want error %v, but got %v", wantErr, gotErr)
+	}
+	if !wantNodes.Equal(gotNodes) {
+		t.Fatalf("want %v, but got %v", wantNodes, gotNodes)
+	}
+}
+
+func TestInitReaderParseSameAsInit(t *testing.T) {
+	want := NewParser()
+	got := NewParser()
+	value := []byte(`{"a":[1,2,{"b":"c"}],"d":"e"}`)
+	want.Init(value)
+	got.InitReader(iotest.OneByteReader(bytes.NewReader(value)))
+	expectSameParse(t, want, got)
+}
+
+func TestInitReaderRandomParseSameAsInit(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	want := NewParser()
+	got := NewParser()
+	for _, value := range values {
+		t.Run(testrun.Name(value), func(t *testing.T) {
+			want.Init(value)
+			got.InitReader(iotest.OneByteReader(bytes.NewReader(value)))
+			expectSameParse(t, want, got)
+		})
+	}
+}
+
+func TestInitReaderNotASingleAllocAfterWarmUp(t *testing.T) {
+	p := NewParser()
+	pool := p.(*jsonParser).pool
+	r := bytes.NewReader(nil)
+	testrun.NotASingleAllocAfterWarmUp(t, pool, func(bs []byte) {
+		r.Reset(bs)
+		p.InitReader(r)
+		if err := debug.Walk(p); err != nil {
+			t.Fatalf("expected EOF, but got %v", err)
+		}
+	})
+}
+
+// repeatReader reads the same element n times.
+type repeatReader struct {
+	element []byte
+	n       int
+	offset  int
+}
+
+func (r *repeatReader) Read(buf []byte) (int, error) {
+	if r.n == 0 {
+		return 0, io.EOF
+	}
+	c := copy(buf, r.element[r.offset:])
+	r.offset += c
+	if r.offset == len(r.element) {
+		r.offset = 0
+		r.n--
+	}
+	return c, nil
+}
+
+func TestInitReaderHeapStaysBounded(t *testing.T) {
+	const n = 100000
+	elements := &repeatReader{element: []byte(`{"key\twith\tescapes":"value\nwith\nescapes"},`), n: n}
+	r := io.MultiReader(strings.NewReader("["), elements, strings.NewReader("null]"))
+	p := NewParser()
+	p.InitReader(r)
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	if err := debug.Walk(p); err != nil {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	// Without reusing the memory of the tokens, every escaped string stays on the heap, which is many megabytes.
+	if after.HeapAlloc > before.HeapAlloc && after.HeapAlloc-before.HeapAlloc > 1<<20 {
+		t.Fatalf("expected the heap to stay bounded while streaming %d elements, but it grew by %d bytes", n, after.HeapAlloc-before.HeapAlloc)
+	}
+	runtime.KeepAlive(p)
+}
@@ -0,0 +1,38 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import "bytes"
+
+// bom is the UTF-8 encoding of the byte order mark U+FEFF.
+var bom = []byte{0xEF, 0xBB, 0xBF}
+
+// skipBOM skips the byte order mark at the start of the input, if there is one.
+// If the input is still shorter than the byte order mark, then more input is read first.
+func (s *scanner) skipBOM() error {
+	for {
+		buf := s.scannable()
+		if bytes.HasPrefix(buf, bom) {
+			s.offset = len(bom)
+			return nil
+		}
+		if !s.more || s.record || len(buf) >= len(bom) || !bytes.HasPrefix(bom, buf) {
+			return nil
+		}
+		if err := s.fill(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,84 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSkipBOM(t *testing.T) {
+	input := []byte("\xEF\xBB\xBF[1]")
+	s := NewScanner(input)
+	s.SetMode(SkipBOM)
+	expect(t, next(s), ArrayOpenKind)
+	if s.Offset() != 3 {
+		t.Fatalf("want offset 3, but got %d", s.Offset())
+	}
+	expect(t, next(s), NumberKind)
+	expect(t, next(s), ArrayCloseKind)
+}
+
+func TestWithoutSkipBOM(t *testing.T) {
+	s := NewScanner([]byte("\xEF\xBB\xBF[1]"))
+	if kind, _, _ := Next(s); kind != UnknownKind {
+		t.Fatalf("expected a byte order mark to be unknown by default, but got %v", kind)
+	}
+}
+
+func TestSkipBOMOnlyAtStart(t *testing.T) {
+	s := NewScanner([]byte("[\xEF\xBB\xBF1]"))
+	s.SetMode(SkipBOM)
+	expect(t, next(s), ArrayOpenKind)
+	if kind, _, _ := Next(s); kind != UnknownKind {
+		t.Fatalf("expected a byte order mark after the start to be unknown, but got %v", kind)
+	}
+}
+
+func TestSkipBOMReader(t *testing.T) {
+	input := []byte("\xEF\xBB\xBF{\"a\":true}")
+	want := NewScanner(input[3:])
+	got := NewReaderScanner(iotest.OneByteReader(bytes.NewReader(input)))
+	got.SetMode(SkipBOM)
+	expectSameTokens(t, want, got)
+}
+
+func TestSkipBOMFeed(t *testing.T) {
+	for _, input := range []string{"\xEF\xBB\xBF[null]", "\xEF\xBB\xBF", "\xEF\xBB", "7"} {
+		want := NewScanner([]byte(input))
+		want.SetMode(SkipBOM)
+		got := NewScanner(nil)
+		got.SetMode(SkipBOM)
+		got.Feed(nil)
+		fed := 0
+		for {
+			wantKind, wantToken, wantErr := Next(want)
+			gotKind, gotToken, gotErr := nextFed(got, []byte(input), &fed)
+			if wantKind != gotKind {
+				t.Fatalf("%q: want kind %v, but got %v", input, wantKind, gotKind)
+			}
+			if (wantErr == nil) != (gotErr == nil) {
+				t.Fatalf("%q: want error %v, but got %v", input, wantErr, gotErr)
+			}
+			if wantErr != nil || wantKind == UnknownKind {
+				break
+			}
+			if !bytes.Equal(wantToken, gotToken) {
+				t.Fatalf("%q: want token %s, but got %s", input, wantToken, gotToken)
+			}
+		}
+	}
+}
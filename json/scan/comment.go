@@ -0,0 +1,142 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Comment returns the offset after a single line (//) or multi-line (/* */) comment.
+// A single line comment does not include the newline that ends it.
+// If the buffer does not start with a comment, then Comment returns 0.
+// If the comment is not complete at the end of the buffer, then Comment returns io.ErrUnexpectedEOF,
+// with the offset at the end of the buffer, or 0 if the buffer only contains a slash.
+func Comment(buf []byte) (int, error) {
+	if len(buf) == 0 || buf[0] != '/' {
+		return 0, nil
+	}
+	if len(buf) == 1 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	switch buf[1] {
+	case '/':
+		if i := bytes.IndexAny(buf[2:], "\n\r"); i >= 0 {
+			return 2 + i, nil
+		}
+		return len(buf), io.ErrUnexpectedEOF
+	case '*':
+		if i := bytes.Index(buf[2:], commentEnd); i >= 0 {
+			return 2 + i + len(commentEnd), nil
+		}
+		return len(buf), io.ErrUnexpectedEOF
+	}
+	return 0, nil
+}
+
+var commentEnd = []byte("*/")
+
+// comments is the range of the comments before a token.
+type comments struct {
+	// found is true if at least one comment was found.
+	found bool
+	// start is the offset of the first comment.
+	start int
+	// end is the offset after the last comment.
+	end int
+	// token is true once the token after the comments has been returned,
+	// so that the comments are only forgotten when the scanner moves on to the next token,
+	// and not when it needs more input before it reaches the token.
+	token bool
+}
+
+// spaceComments returns the offset after the whitespace and comments that start at the offset.
+// If json5 is true, then JSON5 whitespace is skipped, otherwise JSON whitespace.
+// If more is true, then a comment or space that is cut off at the end of the buffer returns io.EOF,
+// with the offset at its start, so that more input can be read before scanning it again.
+// The comments that are found are added to c.
+func spaceComments(buf []byte, offset int, more bool, json5 bool, c *comments) (int, error) {
+	space := &asciiSpace
+	if json5 {
+		space = &asciiSpace5
+	}
+	for offset < len(buf) {
+		b := buf[offset]
+		if space[b] != 0 {
+			offset++
+			continue
+		}
+		if b == '/' {
+			n, err := Comment(buf[offset:])
+			if err == io.ErrUnexpectedEOF {
+				if more {
+					return offset, io.EOF
+				}
+				if n == 0 {
+					// A single slash at the end of the input is not a comment.
+					return offset, nil
+				}
+				if buf[offset+1] == '*' {
+					return offset, ErrUnterminatedComment
+				}
+				// A single line comment can end at the end of the input.
+			}
+			if n == 0 {
+				return offset, nil
+			}
+			if !c.found {
+				c.found = true
+				c.start = offset
+			}
+			offset += n
+			c.end = offset
+			continue
+		}
+		if !json5 || b < utf8.RuneSelf {
+			return offset, nil
+		}
+		r, size := utf8.DecodeRune(buf[offset:])
+		if r == utf8.RuneError && size == 1 {
+			if more && !utf8.FullRune(buf[offset:]) {
+				return offset, io.EOF
+			}
+			return offset, nil
+		}
+		if !isSpace5(r) {
+			return offset, nil
+		}
+		offset += size
+	}
+	return offset, nil
+}
+
+// isSpace5 returns true if the non-ASCII character is JSON5 whitespace.
+func isSpace5(r rune) bool {
+	switch r {
+	case '\u2028', '\u2029', '\uFEFF':
+		return true
+	}
+	return unicode.Is(unicode.Zs, r)
+}
+
+// Comment returns the comments before the current token, see Scanner.Comment.
+func (s *scanner) Comment() []byte {
+	if !s.comments.found {
+		return nil
+	}
+	return s.buf[s.comments.start-s.discarded : s.comments.end-s.discarded]
+}
@@ -0,0 +1,145 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestComment(t *testing.T) {
+	comments := map[string]int{
+		"// a\nb":   4,
+		"// a\r\nb": 4,
+		"/* a */b":  7,
+		"/* a\n*/":  7,
+		"/**/":      4,
+		"/ a":       0,
+		"a":         0,
+	}
+	for input, want := range comments {
+		got, err := Comment([]byte(input))
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("%s: offset want %d, but got %d", input, want, got)
+		}
+	}
+	cutoff := []string{"/", "// a", "/* a *", "/*/"}
+	for _, input := range cutoff {
+		if _, err := Comment([]byte(input)); err != io.ErrUnexpectedEOF {
+			t.Fatalf("%s: expected unexpected EOF, but got %v", input, err)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := []byte("/* a */ [1, // b\n\t2 /* c */ /* d */, 3]// e")
+	s := NewScanner(input)
+	s.SetMode(Comments)
+	want := []struct {
+		kind    Kind
+		comment string
+	}{
+		{ArrayOpenKind, "/* a */"},
+		{NumberKind, ""},
+		{CommaKind, ""},
+		{NumberKind, "// b"},
+		{CommaKind, "/* c */ /* d */"},
+		{NumberKind, ""},
+		{ArrayCloseKind, ""},
+	}
+	for _, w := range want {
+		kind, _, err := Next(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind != w.kind {
+			t.Fatalf("want kind %v, but got %v", w.kind, kind)
+		}
+		if got := string(s.Comment()); got != w.comment {
+			t.Fatalf("want comment %q, but got %q", w.comment, got)
+		}
+	}
+	if _, _, err := Next(s); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
+
+func TestWithoutComments(t *testing.T) {
+	s := NewScanner([]byte("/* a */ 1"))
+	if kind, _, _ := Next(s); kind != UnknownKind {
+		t.Fatalf("expected comments to be unknown in JSON, but got %v", kind)
+	}
+}
+
+func TestCommentsFeed(t *testing.T) {
+	input := []byte("// a\n{\"a\" /* b */ : /* c\n*/ [true, /**/ null] // d\n}//")
+	want := NewScanner(input)
+	want.SetMode(Comments)
+	got := NewScanner(nil)
+	got.SetMode(Comments)
+	got.Feed(nil)
+	fed := 0
+	for {
+		wantKind, wantToken, wantErr := Next(want)
+		gotKind, gotToken, gotErr := nextFed(got, input, &fed)
+		if wantErr != gotErr {
+			t.Fatalf("want error %v, but got %v", wantErr, gotErr)
+		}
+		if wantErr != nil {
+			return
+		}
+		if wantKind != gotKind {
+			t.Fatalf("want kind %v, but got %v", wantKind, gotKind)
+		}
+		if !bytes.Equal(wantToken, gotToken) {
+			t.Fatalf("want token %s, but got %s", wantToken, gotToken)
+		}
+		if !bytes.Equal(want.Comment(), got.Comment()) {
+			t.Fatalf("want comment %q, but got %q", want.Comment(), got.Comment())
+		}
+	}
+}
+
+func TestCommentsReader(t *testing.T) {
+	// The comments are longer than the window, so the window needs to grow to keep them.
+	comment := "/* " + string(bytes.Repeat([]byte("x"), 2*defaultWindowSize)) + " */"
+	input := []byte("[" + comment + "1]")
+	s := NewReaderScanner(bytes.NewReader(input))
+	s.SetMode(Comments)
+	expect(t, next(s), ArrayOpenKind)
+	expect(t, next(s), NumberKind)
+	if got := string(s.Comment()); got != comment {
+		t.Fatalf("want comment of length %d, but got %d", len(comment), len(got))
+	}
+}
+
+func TestUnterminatedComment(t *testing.T) {
+	s := NewScanner([]byte("[1 // 2\n/* 3 ]"))
+	s.SetMode(Comments)
+	expect(t, next(s), ArrayOpenKind)
+	expect(t, next(s), NumberKind)
+	_, _, err := Next(s)
+	syntaxErr, ok := err.(*SyntaxError)
+	if !ok || syntaxErr.Err != ErrUnterminatedComment {
+		t.Fatalf("expected unterminated comment, but got %v", err)
+	}
+	if syntaxErr.Offset != 8 {
+		t.Fatalf("want offset 8, but got %d", syntaxErr.Offset)
+	}
+}
@@ -14,7 +14,10 @@
 
 package scan
 
-import "bytes"
+import (
+	"bytes"
+	"io"
+)
 
 func scanConst(buf []byte, valBytes []byte, err error) (int, error) {
 	if len(buf) < len(valBytes) {
@@ -26,6 +29,20 @@ func scanConst(buf []byte, valBytes []byte, err error) (int, error) {
 	return len(valBytes), nil
 }
 
+// constEnd returns the end offset of the constant or io.ErrUnexpectedEOF,
+// if the buffer ends with a prefix of the constant.
+func constEnd(buf []byte, offset int, valBytes []byte, err error) (int, error) {
+	rest := buf[offset:]
+	if len(rest) < len(valBytes) && bytes.Equal(rest, valBytes[:len(rest)]) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	n, err := scanConst(rest, valBytes, err)
+	if err != nil {
+		return 0, err
+	}
+	return incOffset(buf, offset, n)
+}
+
 var trueBytes = []byte{'t', 'r', 'u', 'e'}
 
 // True returns 4, nil if the prefix of the bytes matches true, otherwise error.
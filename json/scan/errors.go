@@ -25,3 +25,33 @@ var errExpectedTrue = errors.New("expected true")
 var errScanString = errors.New("unable to scan string")
 
 var errScanNumber = errors.New("unable to scan number")
+
+var errScanIdentifier = errors.New("unable to scan identifier")
+
+// ErrNeedMoreInput is returned when the end of the fed input is reached, but Close has not been called yet.
+var ErrNeedMoreInput = errors.New("need more input")
+
+// ErrInputTooLarge is returned when the input is larger than the limit, see Limits.
+var ErrInputTooLarge = errors.New("input exceeds the maximum size")
+
+// ErrStringTooLong is returned when a string is longer than the limit, see Limits.
+var ErrStringTooLong = errors.New("string exceeds the maximum length")
+
+// ErrNumberTooLong is returned when a number has more digits than the limit, see Limits.
+var ErrNumberTooLong = errors.New("number exceeds the maximum number of digits")
+
+// ErrMaxDepthExceeded is returned by the parser and the tagger, when arrays and objects are nested deeper than their maximum depth.
+// It is defined here, so that both can return the same error, without the tagger depending on the parser.
+var ErrMaxDepthExceeded = errors.New("maximum nesting depth exceeded")
+
+// ErrInvalidUTF8 is returned by StrictString, when a string contains invalid UTF-8.
+var ErrInvalidUTF8 = errors.New("invalid UTF-8 in string")
+
+// ErrLoneSurrogate is returned by StrictString, when a string contains an escaped surrogate that is not part of a surrogate pair.
+var ErrLoneSurrogate = errors.New("unpaired surrogate in string")
+
+// ErrUnsafeInteger is returned when an integer cannot be represented exactly by an IEEE 754 double, see SafeIntegers.
+var ErrUnsafeInteger = errors.New("integer outside of the range [-(2^53)+1, (2^53)-1]")
+
+// ErrUnterminatedComment is returned when a multi-line comment is not closed before the end of the input, see Comments.
+var ErrUnterminatedComment = errors.New("unterminated comment")
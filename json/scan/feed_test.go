@@ -0,0 +1,109 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go-json/json/rand"
+)
+
+// nextFed feeds one byte at a time, until the scanner stops asking for more input.
+func nextFed(s Scanner, input []byte, fed *int) (Kind, []byte, error) {
+	for {
+		kind, token, err := Next(s)
+		if err != ErrNeedMoreInput {
+			return kind, token, err
+		}
+		if *fed == len(input) {
+			s.Close()
+			continue
+		}
+		s.Feed(input[*fed : *fed+1])
+		*fed++
+	}
+}
+
+func TestFeedRandomScan(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	for _, value := range values {
+		name := testrun.Name(value)
+		t.Run(name, func(t *testing.T) {
+			want := NewScanner(value)
+			got := NewScanner(nil)
+			// Feeding an empty chunk, means we are expecting more input.
+			got.Feed(nil)
+			fed := 0
+			for {
+				wantKind, wantToken, wantErr := Next(want)
+				gotKind, gotToken, gotErr := nextFed(got, value, &fed)
+				if wantErr != gotErr {
+					t.Fatalf("want error %v, but got %v", wantErr, gotErr)
+				}
+				if wantErr != nil {
+					return
+				}
+				if wantKind != gotKind {
+					t.Fatalf("want kind %v, but got %v", wantKind, gotKind)
+				}
+				if !bytes.Equal(wantToken, gotToken) {
+					t.Fatalf("want token %s, but got %s", wantToken, gotToken)
+				}
+			}
+		})
+	}
+}
+
+func TestFeedNeedMoreInputBeforeFirstChunk(t *testing.T) {
+	s := NewScanner(nil)
+	if _, _, err := Next(s); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	s.Feed([]byte(`[`))
+	expect(t, next(s), ArrayOpenKind)
+	s.Close()
+	if _, _, err := Next(s); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
+
+func TestFeedNeedMoreInput(t *testing.T) {
+	s := NewScanner(nil)
+	s.Feed([]byte(`[12`))
+	expect(t, next(s), ArrayOpenKind)
+	if _, _, err := Next(s); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	s.Feed([]byte(`3]`))
+	kind, token, err := Next(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != NumberKind || string(token) != "123" {
+		t.Fatalf("expected number 123, but got %v %s", kind, token)
+	}
+	expect(t, next(s), ArrayCloseKind)
+	if _, _, err := Next(s); err != ErrNeedMoreInput {
+		t.Fatalf("expected need more input, but got %v", err)
+	}
+	s.Close()
+	if _, _, err := Next(s); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"errors"
+	"io"
+)
+
+// The errors of the JSON grammar are shared by the parser and the formatter,
+// so that both return the same *SyntaxError for the same invalid input.
+
+// ErrExpectedValue is returned when a token is found where a value was expected.
+var ErrExpectedValue = errors.New("expected value")
+
+// ErrExpectedCommaOrCloseBracket is returned when a token is found after an element of an array that is not ',' or ']'.
+var ErrExpectedCommaOrCloseBracket = errors.New("expected ',' or ']'")
+
+// ErrExpectedCommaOrCloseCurly is returned when a token is found after a value of an object that is not ',' or '}'.
+var ErrExpectedCommaOrCloseCurly = errors.New("expected ',' or '}'")
+
+// ErrExpectedStringOrCloseCurly is returned when a token is found at the start of an object that is not a key or '}'.
+var ErrExpectedStringOrCloseCurly = errors.New("expected '\"' or '}'")
+
+// ErrExpectedString is returned when a token is found after a comma in an object that is not a key.
+var ErrExpectedString = errors.New("expected '\"'")
+
+// ErrExpectedColon is returned when a token is found after a key that is not ':'.
+var ErrExpectedColon = errors.New("expected ':'")
+
+// ErrUnexpectedEndOfInput is returned when the input ends before the value is complete.
+// It matches io.ErrShortBuffer using errors.Is.
+var ErrUnexpectedEndOfInput error = &shortBufferError{"unexpected end of input"}
+
+// ErrExpectedEndOfInput is returned when a token is found after the value is complete.
+// It matches io.ErrShortBuffer using errors.Is.
+var ErrExpectedEndOfInput error = &shortBufferError{"expected end of input"}
+
+// shortBufferError is an error about where the input ends, which matches io.ErrShortBuffer using errors.Is.
+type shortBufferError struct {
+	msg string
+}
+
+func (e *shortBufferError) Error() string {
+	return e.msg
+}
+
+func (e *shortBufferError) Is(target error) bool {
+	return target == io.ErrShortBuffer
+}
+
+// The kinds of tokens that were expected are reported in a *SyntaxError.
+// These slices are shared and must not be modified.
+var (
+	ExpectedValue = []Kind{
+		NullKind, FalseKind, TrueKind, NumberKind, StringKind, ArrayOpenKind, ObjectOpenKind,
+	}
+	ExpectedValueOrCloseBracket = []Kind{
+		NullKind, FalseKind, TrueKind, NumberKind, StringKind, ArrayOpenKind, ObjectOpenKind, ArrayCloseKind,
+	}
+	ExpectedCommaOrCloseBracket = []Kind{CommaKind, ArrayCloseKind}
+	ExpectedCommaOrCloseCurly   = []Kind{CommaKind, ObjectCloseKind}
+	ExpectedStringOrCloseCurly  = []Kind{StringKind, ObjectCloseKind}
+	ExpectedString              = []Kind{StringKind}
+	ExpectedColon               = []Kind{ColonKind}
+)
@@ -0,0 +1,294 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// JSON5 (https://spec.json5.org) is a superset of JSON that also allows:
+//   - single line and multi-line comments,
+//   - more whitespace characters,
+//   - single quoted strings, with more escapes and line continuations,
+//   - identifiers as object keys,
+//   - a leading '+', leading and trailing decimal points, hexadecimal integers, Infinity and NaN,
+//   - trailing commas, which are handled by the parser.
+
+// looking up in an array is faster than a map.
+var kinds5 = [256]Kind{}
+
+// asciiSpace5 are the ASCII whitespace characters of JSON5.
+var asciiSpace5 = [256]uint8{'\t': 1, '\n': 1, '\v': 1, '\f': 1, '\r': 1, ' ': 1}
+
+// identifierStart and identifierPart are the ASCII characters that can start or continue an identifier.
+var identifierStart = [256]bool{'$': true, '_': true}
+var identifierPart = [256]bool{}
+
+func init() {
+	kinds5 = kinds
+	kinds5['\''] = StringKind
+	kinds5['+'] = NumberKind
+	kinds5['.'] = NumberKind
+	for c := 'a'; c <= 'z'; c++ {
+		identifierStart[c] = true
+		identifierStart[c-'a'+'A'] = true
+	}
+	identifierPart = identifierStart
+	for c := '0'; c <= '9'; c++ {
+		identifierPart[c] = true
+	}
+	for c, ok := range identifierStart {
+		if ok {
+			kinds5[c] = IdentifierKind
+		}
+	}
+	kinds5['\\'] = IdentifierKind
+	for c := utf8.RuneSelf; c < 256; c++ {
+		kinds5[c] = IdentifierKind
+	}
+}
+
+// kind5 returns the kind of the JSON5 token at the start of the buffer.
+// Words are classified as true, false, null, a number (Infinity or NaN) or an identifier.
+// The scanner does not know whether the word is an object key, so the tokenizer reclassifies keywords that are used as keys.
+func kind5(buf []byte) Kind {
+	kind := kinds5[buf[0]]
+	if kind == IdentifierKind {
+		kind = word(buf)
+	}
+	return kind
+}
+
+// word returns the kind of the word at the start of the buffer.
+func word(buf []byte) Kind {
+	n, _ := identifier(buf)
+	w := buf[:n]
+	switch {
+	case bytes.Equal(w, trueBytes):
+		return TrueKind
+	case bytes.Equal(w, falseBytes):
+		return FalseKind
+	case bytes.Equal(w, nullBytes):
+		return NullKind
+	case bytes.Equal(w, infinityBytes), bytes.Equal(w, nanBytes):
+		return NumberKind
+	}
+	return IdentifierKind
+}
+
+// nextEnd5 is nextEnd for JSON5.
+func nextEnd5(kind Kind, buf []byte, offset int) (int, error) {
+	switch kind {
+	case StringKind:
+		n, err := string5End(buf[offset:])
+		if err != nil {
+			return 0, err
+		}
+		return incOffset(buf, offset, n)
+	case NumberKind:
+		return numberEnd(buf, offset, number5, &isFailState5)
+	case IdentifierKind, TrueKind, FalseKind, NullKind:
+		n, err := identifier(buf[offset:])
+		if err != nil {
+			return 0, err
+		}
+		if offset+n == len(buf) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		return end5(kind, buf, offset)
+	}
+	return NextEnd(kind, buf, offset)
+}
+
+// end5 is NextEnd for JSON5.
+func end5(kind Kind, buf []byte, offset int) (int, error) {
+	var n int
+	var err error
+	switch kind {
+	case StringKind:
+		n, err = JSON5String(buf[offset:])
+	case NumberKind:
+		n, err = JSON5Number(buf[offset:])
+	case IdentifierKind:
+		n, err = Identifier(buf[offset:])
+	default:
+		return NextEnd(kind, buf, offset)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return incOffset(buf, offset, n)
+}
+
+// Identifier returns the offset after an identifier, which JSON5 allows as an object key.
+// The identifier BNF:
+// identifier := start | identifier part
+// start := unicodeLetter | '$' | '_' | '\\' 'u' hex hex hex hex
+// part := start | unicodeCombiningMark | unicodeDigit | unicodeConnectorPunctuation | ZWNJ | ZWJ
+func Identifier(buf []byte) (int, error) {
+	n, err := identifier(buf)
+	if err != nil || n == 0 {
+		return 0, errScanIdentifier
+	}
+	return n, nil
+}
+
+// identifier returns the offset after the prefix of an identifier.
+// If the offset is equal to the length of the buffer, then the identifier could continue after the end of the buffer.
+// It returns io.ErrUnexpectedEOF if an escape or a UTF-8 encoded character is cut off at the end of the buffer.
+func identifier(buf []byte) (int, error) {
+	i := 0
+	for i < len(buf) {
+		c := buf[i]
+		if c == '\\' {
+			if len(buf) < i+6 {
+				return 0, io.ErrUnexpectedEOF
+			}
+			if buf[i+1] != 'u' || !hextable[buf[i+2]] || !hextable[buf[i+3]] || !hextable[buf[i+4]] || !hextable[buf[i+5]] {
+				return 0, errScanIdentifier
+			}
+			if !isIdentifierRune(hex4(buf[i+2], buf[i+3], buf[i+4], buf[i+5]), i > 0) {
+				return 0, errScanIdentifier
+			}
+			i += 6
+			continue
+		}
+		if c < utf8.RuneSelf {
+			if !identifierPart[c] || (i == 0 && !identifierStart[c]) {
+				return i, nil
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(buf[i:])
+		if r == utf8.RuneError && size == 1 {
+			if !utf8.FullRune(buf[i:]) {
+				return 0, io.ErrUnexpectedEOF
+			}
+			return i, nil
+		}
+		if !isIdentifierRune(r, i > 0) {
+			return i, nil
+		}
+		i += size
+	}
+	return i, nil
+}
+
+// isIdentifierRune returns true if the character can start an identifier, or continue it if part is true.
+func isIdentifierRune(r rune, part bool) bool {
+	if r < utf8.RuneSelf {
+		if part {
+			return identifierPart[r]
+		}
+		return identifierStart[r]
+	}
+	if unicode.IsLetter(r) || unicode.Is(unicode.Nl, r) {
+		return true
+	}
+	if !part {
+		return false
+	}
+	return r == '\u200C' || r == '\u200D' || unicode.In(r, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc)
+}
+
+// JSON5String returns the offset after a JSON5 string.
+// The JSON5 string BNF:
+// string := '"' characters '"' | "'" characters "'"
+// character := any character - quote - '\\' - lineTerminator | '\\' escape | '\\' lineTerminatorSequence
+// escape := any character - lineTerminator - '1' . '9' - 'x' - 'u' | '0' (not followed by a digit) | 'x' hex hex | 'u' hex hex hex hex
+func JSON5String(buf []byte) (int, error) {
+	n, err := string5End(buf)
+	if err != nil {
+		return 0, errScanString
+	}
+	return n, nil
+}
+
+// string5End is the same as JSON5String, except that it returns io.ErrUnexpectedEOF,
+// if the end of the buffer was reached before the string was closed.
+func string5End(buf []byte) (int, error) {
+	if len(buf) == 0 || (buf[0] != '"' && buf[0] != '\'') {
+		return 0, errScanString
+	}
+	quote := buf[0]
+	i := 1
+	for i < len(buf) {
+		c := buf[i]
+		i++
+		switch c {
+		case quote:
+			return i, nil
+		case '\n', '\r':
+			return 0, errScanString
+		case '\\':
+			if i >= len(buf) {
+				return 0, io.ErrUnexpectedEOF
+			}
+			n, err := escape5(buf[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+// escape5 returns the length of the JSON5 escape that follows a backslash.
+func escape5(buf []byte) (int, error) {
+	switch c := buf[0]; {
+	case c == 'x':
+		return hexEscape(buf, 2)
+	case c == 'u':
+		return hexEscape(buf, 4)
+	case c == '0':
+		if len(buf) < 2 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if '0' <= buf[1] && buf[1] <= '9' {
+			return 0, errScanString
+		}
+	case '1' <= c && c <= '9':
+		return 0, errScanString
+	case c == '\r':
+		// A line continuation can be "\r\n".
+		if len(buf) < 2 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if buf[1] == '\n' {
+			return 2, nil
+		}
+	}
+	// Any other character, including a line terminator for a line continuation, escapes itself.
+	// The rest of a UTF-8 encoded character is scanned as part of the string.
+	return 1, nil
+}
+
+// hexEscape returns the length of the escape character followed by the given number of hexadecimal digits.
+func hexEscape(buf []byte, digits int) (int, error) {
+	for i := 1; i <= digits; i++ {
+		if i >= len(buf) {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if !hextable[buf[i]] {
+			return 0, errScanString
+		}
+	}
+	return 1 + digits, nil
+}
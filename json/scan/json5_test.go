@@ -0,0 +1,328 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestJSON5Number(t *testing.T) {
+	valid := map[string]int{
+		"1":          1,
+		"+1":         2,
+		"-1":         2,
+		".5":         2,
+		"+.5":        3,
+		"-.5e2":      5,
+		"5.":         2,
+		"5.e1":       4,
+		"5. ":        2,
+		"0x1F":       4,
+		"0XaB,":      4,
+		"-0x10":      5,
+		"Infinity":   8,
+		"+Infinity]": 9,
+		"-Infinity":  9,
+		"NaN":        3,
+		"-NaN ":      4,
+	}
+	invalid := []string{
+		".",
+		"+",
+		"+.",
+		"0x",
+		"0xG",
+		"01",
+		"Inf",
+		"Na",
+		"1e",
+		"++1",
+	}
+	for input, want := range valid {
+		got, err := JSON5Number([]byte(input))
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("%s: offset want %d, but got %d", input, want, got)
+		}
+	}
+	for _, input := range invalid {
+		if _, err := JSON5Number([]byte(input)); err == nil {
+			t.Fatalf("%s: expected error", input)
+		}
+	}
+}
+
+func TestParseJSON5Number(t *testing.T) {
+	ints := map[string]int64{
+		"+1":                  1,
+		"0x1F":                31,
+		"-0x1f":               -31,
+		"0x7FFFFFFFFFFFFFFF":  math.MaxInt64,
+		"-0x8000000000000000": math.MinInt64,
+	}
+	for input, want := range ints {
+		_, got, ok, _, _, _ := ParseJSON5Number([]byte(input))
+		if !ok || got != want {
+			t.Fatalf("%s: want int %d, but got %d, %v", input, want, got, ok)
+		}
+	}
+	floats := map[string]float64{
+		".5":                  0.5,
+		"-.5e2":               -50,
+		"5.":                  5,
+		"+1.5":                1.5,
+		"0x8000000000000000":  1 << 63,
+		"0x10000000000000000": 1 << 64,
+		"Infinity":            math.Inf(1),
+		"+Infinity":           math.Inf(1),
+		"-Infinity":           math.Inf(-1),
+	}
+	for input, want := range floats {
+		_, _, _, got, ok, _ := ParseJSON5Number([]byte(input))
+		if !ok || got != want {
+			t.Fatalf("%s: want float %v, but got %v, %v", input, want, got, ok)
+		}
+	}
+	_, _, _, got, ok, _ := ParseJSON5Number([]byte("-NaN"))
+	if !ok || !math.IsNaN(got) {
+		t.Fatalf("want NaN, but got %v", got)
+	}
+	// JSON numbers are still parsed as before.
+	if _, _, _, _, _, decimalok := ParseJSON5Number([]byte("123456789012345678901234567890")); !decimalok {
+		t.Fatalf("want decimal")
+	}
+}
+
+func TestJSON5String(t *testing.T) {
+	valid := map[string]int{
+		`'abc'`:        5,
+		`'a"b'`:        5,
+		`"a'b"`:        5,
+		`'a\'b' `:      6,
+		`'\x41\u0041'`: 12,
+		`'\0'`:         4,
+		`'\v\q'`:       6,
+		"'a\\\nb'":     6,
+		"'a\\\r\nb'":   7,
+		"'\u2028'":     5,
+	}
+	invalid := []string{
+		`'abc"`,
+		"'a\nb'",
+		"'a\rb'",
+		`'\1'`,
+		`'\01'`,
+		`'\x4'`,
+		`'\u004'`,
+		`abc`,
+	}
+	for input, want := range valid {
+		got, err := JSON5String([]byte(input))
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("%s: offset want %d, but got %d", input, want, got)
+		}
+	}
+	for _, input := range invalid {
+		if _, err := JSON5String([]byte(input)); err == nil {
+			t.Fatalf("%s: expected error", input)
+		}
+	}
+}
+
+func TestIdentifier(t *testing.T) {
+	valid := map[string]int{
+		"a":             1,
+		"abc:":          3,
+		"$_a1 ":         4,
+		"_":             1,
+		"ZZ":            2,
+		"\\u0061bc":     8,
+		"a\\u0031":      7,
+		"\u00e9t\u00e9": 5,
+		"a\u0301b":      4,
+		"\u03c0\u2028":  2,
+	}
+	invalid := []string{
+		"1a",
+		"-",
+		"\\u0031",
+		"\\x61",
+		"\\u00",
+		"\u0301",
+		":",
+	}
+	for input, want := range valid {
+		got, err := Identifier([]byte(input))
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("%s: offset want %d, but got %d", input, want, got)
+		}
+	}
+	for _, input := range invalid {
+		if _, err := Identifier([]byte(input)); err == nil {
+			t.Fatalf("%s: expected error", input)
+		}
+	}
+}
+
+// json5Example is the example from https://json5.org
+var json5Example = `// This file is written in JSON5 syntax, naturally, but npm needs a regular
+// JSON file, so compile via ` + "`npm run build`" + `. Be sure to keep both in sync!
+
+{
+  name: 'json5',
+  version: '0.5.0',
+  description: 'JSON for the ES5 era.',
+  keywords: ['json', 'es5'],
+  author: 'Aseem Kishore <aseem.kishore@gmail.com>',
+  contributors: [
+    // TODO: Should we remove this section in favor of GitHub's list?
+    // https://github.com/aseemk/json5/contributors
+    'Max Nanasy <max.nanasy@gmail.com>',
+  ],
+  main: 'lib/json5.js',
+  bin: 'lib/cli.js',
+  files: ["lib/"],
+  dependencies: {},
+  /* multi-line
+     comment */ lineBreaks: "Look, Mom! \
+No \\n's!",
+  hex: 0xDEADbeef, half: .5, delta: +10, to: Infinity, nan: NaN, trailing: 8.,
+  nbsp:  true, null: null,
+}
+`
+
+func scanJSON5Kinds(t *testing.T, s Scanner) []Kind {
+	t.Helper()
+	var kinds []Kind
+	for {
+		kind, _, err := Next(s)
+		if err == io.EOF {
+			return kinds
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		kinds = append(kinds, kind)
+	}
+}
+
+func TestJSON5Scanner(t *testing.T) {
+	s := NewScanner([]byte(json5Example))
+	s.SetMode(JSON5)
+	kinds := scanJSON5Kinds(t, s)
+	if len(kinds) == 0 || kinds[0] != ObjectOpenKind || kinds[len(kinds)-1] != ObjectCloseKind {
+		t.Fatalf("unexpected kinds %v", kinds)
+	}
+	want := []Kind{IdentifierKind, ColonKind, NumberKind, CommaKind}
+	for _, key := range []string{"hex", "half", "delta", "to", "nan", "trailing"} {
+		i := bytes.Index([]byte(json5Example), []byte(key+":"))
+		s.Init([]byte(json5Example[i:]))
+		for j, w := range want {
+			kind, token, err := Next(s)
+			if err != nil {
+				t.Fatalf("%s: %v", key, err)
+			}
+			if kind != w {
+				t.Fatalf("%s: token %d: want %v, but got %v %s", key, j, w, kind, token)
+			}
+		}
+	}
+}
+
+func TestJSON5Feed(t *testing.T) {
+	want := NewScanner([]byte(json5Example))
+	want.SetMode(JSON5)
+	got := NewScanner(nil)
+	got.SetMode(JSON5)
+	got.Feed(nil)
+	input := []byte(json5Example)
+	fed := 0
+	for {
+		wantKind, wantToken, wantErr := Next(want)
+		gotKind, gotToken, gotErr := nextFed(got, input, &fed)
+		if wantErr != gotErr {
+			t.Fatalf("want error %v, but got %v", wantErr, gotErr)
+		}
+		if wantErr != nil {
+			return
+		}
+		if wantKind != gotKind {
+			t.Fatalf("want kind %v, but got %v", wantKind, gotKind)
+		}
+		if !bytes.Equal(wantToken, gotToken) {
+			t.Fatalf("want token %s, but got %s", wantToken, gotToken)
+		}
+	}
+}
+
+func TestJSON5Words(t *testing.T) {
+	words := map[string]Kind{
+		"true":     TrueKind,
+		"false":    FalseKind,
+		"null":     NullKind,
+		"Infinity": NumberKind,
+		"NaN":      NumberKind,
+		"trueish":  IdentifierKind,
+		"nullable": IdentifierKind,
+		"NaNa":     IdentifierKind,
+		"Infinite": IdentifierKind,
+		"\u00e9":   IdentifierKind,
+	}
+	for input, want := range words {
+		s := NewScanner([]byte(input))
+		s.SetMode(JSON5)
+		kind, token, err := Next(s)
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if kind != want || string(token) != input {
+			t.Fatalf("%s: want %v, but got %v %s", input, want, kind, token)
+		}
+	}
+}
+
+func TestJSON5UnterminatedComment(t *testing.T) {
+	s := NewScanner([]byte("[1, /* 2 ]"))
+	s.SetMode(JSON5)
+	expect(t, next(s), ArrayOpenKind)
+	expect(t, next(s), NumberKind)
+	expect(t, next(s), CommaKind)
+	_, _, err := Next(s)
+	syntaxErr, ok := err.(*SyntaxError)
+	if !ok || syntaxErr.Err != ErrUnterminatedComment {
+		t.Fatalf("expected unterminated comment, but got %v", err)
+	}
+	if syntaxErr.Offset != 4 {
+		t.Fatalf("want offset 4, but got %d", syntaxErr.Offset)
+	}
+}
+
+func TestWithoutJSON5(t *testing.T) {
+	s := NewScanner([]byte("'a'"))
+	if kind, _, _ := Next(s); kind != UnknownKind {
+		t.Fatalf("expected single quoted strings to be unknown in JSON, but got %v", kind)
+	}
+}
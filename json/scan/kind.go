@@ -15,7 +15,7 @@
 package scan
 
 // Kind of the token that is scanned.
-// This is represented by one for following bytes: {:}[,]"0tfn
+// This is represented by one for following bytes: {:}[,]"0tfna
 type Kind byte
 
 const UnknownKind = Kind(0)
@@ -90,6 +90,13 @@ func (k Kind) IsNull() bool {
 	return k == NullKind
 }
 
+// IdentifierKind is an unquoted object key, which is only scanned in JSON5 mode.
+const IdentifierKind = Kind('a')
+
+func (k Kind) IsIdentifier() bool {
+	return k == IdentifierKind
+}
+
 func (k Kind) String() string {
 	switch k {
 	case UnknownKind:
@@ -116,6 +123,8 @@ func (k Kind) String() string {
 		return "colonKind"
 	case ObjectCloseKind:
 		return "objectClose"
+	case IdentifierKind:
+		return "identifier"
 	}
 	return "other"
 }
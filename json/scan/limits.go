@@ -0,0 +1,81 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+// Limits restricts the size of the input and of its tokens, so that untrusted input can be scanned safely.
+// A limit of zero means that it is not limited.
+type Limits struct {
+	// MaxInputBytes is the maximum size of the whole input in bytes.
+	MaxInputBytes int
+	// MaxStringBytes is the maximum number of bytes between the quotes of a string, before it is unquoted.
+	// It also limits the number of bytes of a JSON5 identifier.
+	MaxStringBytes int
+	// MaxNumberDigits is the maximum number of digits of a number, including the digits of its fraction and exponent.
+	MaxNumberDigits int
+}
+
+// Limit sets the limits of the scanner, which are kept when the scanner is restarted with Init, InitReader or Feed.
+func (s *scanner) Limit(limits Limits) {
+	s.limits = limits
+}
+
+// checkInput returns ErrInputTooLarge if more input has been read or fed than the limit allows.
+func (s *scanner) checkInput() error {
+	if s.limits.MaxInputBytes > 0 && s.discarded+len(s.buf) > s.limits.MaxInputBytes {
+		return ErrInputTooLarge
+	}
+	return nil
+}
+
+// checkToken returns an error if the string or number token exceeds the limits.
+// The token may be cut off, in which case it is checked whether the whole token will definitely exceed the limits.
+func (s *scanner) checkToken(kind Kind, token []byte, cutoff bool) error {
+	switch kind {
+	case StringKind:
+		if s.limits.MaxStringBytes == 0 {
+			return nil
+		}
+		// Do not count the quotes.
+		n := len(token) - 1
+		if !cutoff {
+			n--
+		}
+		if n > s.limits.MaxStringBytes {
+			return s.SyntaxError(ErrStringTooLong, kind, nil)
+		}
+	case IdentifierKind:
+		if s.limits.MaxStringBytes > 0 && len(token) > s.limits.MaxStringBytes {
+			return s.SyntaxError(ErrStringTooLong, kind, nil)
+		}
+	case NumberKind:
+		if s.limits.MaxNumberDigits == 0 {
+			return nil
+		}
+		if digits(token) > s.limits.MaxNumberDigits {
+			return s.SyntaxError(ErrNumberTooLong, kind, nil)
+		}
+	}
+	return nil
+}
+
+func digits(number []byte) int {
+	n := 0
+	for _, c := range number {
+		if '0' <= c && c <= '9' {
+			n++
+		}
+	}
+	return n
+}
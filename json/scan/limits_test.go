@@ -0,0 +1,116 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func expectLimitError(t *testing.T, err error, sentinel error, offset int) {
+	t.Helper()
+	if !errors.Is(err, sentinel) {
+		t.Fatalf("expected %v, but got %v", sentinel, err)
+	}
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected syntax error, but got %v", err)
+	}
+	if syntaxErr.Offset != offset {
+		t.Fatalf("want offset %d, but got %d", offset, syntaxErr.Offset)
+	}
+}
+
+// nextUntilError calls Next until it returns an error.
+func nextUntilError(s Scanner) error {
+	for {
+		if _, _, err := Next(s); err != nil {
+			return err
+		}
+	}
+}
+
+func TestLimitString(t *testing.T) {
+	s := NewScanner([]byte(`["abc","a\"cd"]`))
+	s.Limit(Limits{MaxStringBytes: 3})
+	expectLimitError(t, nextUntilError(s), ErrStringTooLong, 7)
+}
+
+func TestLimitNumber(t *testing.T) {
+	s := NewScanner([]byte(`[123,-1.5e10]`))
+	s.Limit(Limits{MaxNumberDigits: 3})
+	expectLimitError(t, nextUntilError(s), ErrNumberTooLong, 5)
+}
+
+// endless is a reader that returns a prefix followed by an endless repetition of a byte.
+type endless struct {
+	prefix string
+	b      byte
+}
+
+func (e *endless) Read(p []byte) (int, error) {
+	n := copy(p, e.prefix)
+	e.prefix = e.prefix[n:]
+	for i := range p[n:] {
+		p[n+i] = e.b
+	}
+	return len(p), nil
+}
+
+func TestLimitStringReader(t *testing.T) {
+	s := NewReaderScanner(&endless{prefix: `["`, b: 'a'}).(*scanner)
+	s.Limit(Limits{MaxStringBytes: 100})
+	expectLimitError(t, nextUntilError(s), ErrStringTooLong, 1)
+	if cap(s.window) != defaultWindowSize {
+		t.Fatalf("expected the window to stay %d bytes, but it grew to %d", defaultWindowSize, cap(s.window))
+	}
+}
+
+func TestLimitInput(t *testing.T) {
+	input := []byte(`[1, 2, 3]`)
+	s := NewScanner(input)
+	s.Limit(Limits{MaxInputBytes: len(input)})
+	if err := nextUntilError(s); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+	s.Limit(Limits{MaxInputBytes: len(input) - 1})
+	s.Init(input)
+	if err := nextUntilError(s); err != ErrInputTooLarge {
+		t.Fatalf("expected %v, but got %v", ErrInputTooLarge, err)
+	}
+}
+
+func TestLimitInputReader(t *testing.T) {
+	s := NewReaderScanner(&endless{prefix: `[`, b: ' '})
+	s.Limit(Limits{MaxInputBytes: 3 * defaultWindowSize})
+	if err := nextUntilError(s); err != ErrInputTooLarge {
+		t.Fatalf("expected %v, but got %v", ErrInputTooLarge, err)
+	}
+}
+
+func TestLimitInputFeed(t *testing.T) {
+	s := NewScanner(nil)
+	s.Limit(Limits{MaxInputBytes: 10})
+	s.Feed([]byte(`[1, `))
+	if err := nextUntilError(s); err != ErrNeedMoreInput {
+		t.Fatalf("expected %v, but got %v", ErrNeedMoreInput, err)
+	}
+	s.Feed([]byte(strings.Repeat(" ", 10)))
+	if err := nextUntilError(s); err != ErrInputTooLarge {
+		t.Fatalf("expected %v, but got %v", ErrInputTooLarge, err)
+	}
+}
@@ -0,0 +1,125 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import "io"
+
+// Mode is a set of flags that change what the scanner accepts.
+type Mode uint
+
+const (
+	// StrictUTF8 rejects strings that contain invalid UTF-8 or escaped surrogates that are not part of a surrogate pair,
+	// see StrictString.
+	StrictUTF8 Mode = 1 << iota
+	// SafeIntegers rejects integers outside of the range [-(2^53)+1, (2^53)-1],
+	// which cannot be represented exactly by an IEEE 754 double, see RFC 7493.
+	// Numbers with a fraction or an exponent are not checked.
+	SafeIntegers
+	// Comments allows single line (//) and multi-line (/* */) comments wherever whitespace is allowed.
+	// The comments before the current token are returned by Scanner.Comment.
+	Comments
+	// NonFinite allows the numbers NaN, Infinity and -Infinity, as they are written by Python's json.dumps.
+	NonFinite
+	// JSON5 scans JSON5 (https://spec.json5.org) instead of JSON:
+	// comments, more whitespace, single quoted strings, identifiers and more number formats.
+	// Comments are always allowed in JSON5.
+	// StrictUTF8 and SafeIntegers are not applied to JSON5 strings and numbers.
+	JSON5
+	// SkipBOM skips a UTF-8 byte order mark (EF BB BF) at the start of the input.
+	SkipBOM
+)
+
+// SetMode sets the mode of the scanner, which is kept when the scanner is restarted with Init, InitReader or Feed.
+func (s *scanner) SetMode(mode Mode) {
+	s.mode = mode
+}
+
+// end returns the end offset of the token, according to the mode of the scanner.
+func (s *scanner) end(kind Kind, buf []byte, offset int) (int, error) {
+	if s.mode&JSON5 != 0 {
+		return end5(kind, buf, offset)
+	}
+	switch kind {
+	case StringKind:
+		if s.mode&StrictUTF8 != 0 {
+			return scanStrictString(buf, offset)
+		}
+	case NumberKind:
+		scan := scanNumber
+		if s.mode&NonFinite != 0 {
+			scan = scanNonFiniteNumber
+		}
+		if s.mode&SafeIntegers != 0 {
+			return scanSafeInteger(buf, offset, scan)
+		}
+		return scan(buf, offset)
+	}
+	return NextEnd(kind, buf, offset)
+}
+
+// nextStart is NextStart, according to the mode of the scanner.
+// The comments that are skipped are kept in the input coordinates of the scanner, so that they survive a refill of the window.
+func (s *scanner) nextStart(buf []byte, offset int) (Kind, int, error) {
+	if s.mode&(Comments|JSON5) == 0 {
+		kind, start, err := NextStart(buf, offset)
+		if err == nil && kind == UnknownKind {
+			kind = s.kind(buf[start])
+		}
+		return kind, start, err
+	}
+	json5 := s.mode&JSON5 != 0
+	var c comments
+	start, err := spaceComments(buf, offset, s.more && !s.record, json5, &c)
+	if c.found {
+		if !s.comments.found {
+			s.comments.found = true
+			s.comments.start = s.discarded + c.start
+		}
+		s.comments.end = s.discarded + c.end
+	}
+	if err == io.EOF {
+		return UnknownKind, start, err
+	}
+	if err != nil {
+		s.start = s.discarded + start
+		return UnknownKind, start, s.SyntaxError(err, UnknownKind, nil)
+	}
+	if start == len(buf) {
+		return UnknownKind, start, io.EOF
+	}
+	if json5 {
+		return kind5(buf[start:]), start, nil
+	}
+	return s.kind(buf[start]), start, nil
+}
+
+// kind returns the kind of the token that starts with the byte, according to the mode of the scanner.
+func (s *scanner) kind(c byte) Kind {
+	if s.mode&NonFinite != 0 && (c == 'N' || c == 'I') {
+		return NumberKind
+	}
+	return kinds[c]
+}
+
+// nextEnd is nextEnd, according to the mode of the scanner.
+func (s *scanner) nextEnd(kind Kind, buf []byte, offset int) (int, error) {
+	if s.mode&JSON5 != 0 {
+		return nextEnd5(kind, buf, offset)
+	}
+	if kind == NumberKind && s.mode&NonFinite != 0 {
+		return numberEnd(buf, offset, nonFiniteNumber, &isFailState)
+	}
+	return nextEnd(kind, buf, offset)
+}
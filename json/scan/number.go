@@ -14,7 +14,13 @@
 
 package scan
 
-import "github.com/katydid/parser-go-json/json/internal/fork/strconv"
+import (
+	"bytes"
+	"math"
+	"math/bits"
+
+	"github.com/katydid/parser-go-json/json/internal/fork/strconv"
+)
 
 // Number returns the offset after the prefix of a valid number.
 // The number BNF:
@@ -27,6 +33,21 @@ import "github.com/katydid/parser-go-json/json/internal/fork/strconv"
 // exponent := "" | 'E' sign digits | 'e' sign digits
 // sign := "" | '+' | '-'
 func Number(buf []byte) (int, error) {
+	offset, state := number(buf)
+	if isFailState[state] {
+		return 0, errScanNumber
+	}
+	return offset, nil
+}
+
+// number returns the offset after the prefix of a number and the state the number state machine stopped in.
+// If the offset is equal to the length of the buffer, then the number could continue after the end of the buffer.
+func number(buf []byte) (int, byte) {
+	return numberWith(&machine, buf)
+}
+
+// numberWith is the same as number, but for the given state machine.
+func numberWith(machine *[256][256]dst, buf []byte) (int, byte) {
 	state := StateStart // start
 	offset := 0
 	for offset < len(buf) {
@@ -37,10 +58,7 @@ func Number(buf []byte) (int, error) {
 		}
 		offset += 1
 	}
-	if isFailState[state] {
-		return 0, errScanNumber
-	}
-	return offset, nil
+	return offset, state
 }
 
 var isFailState = [256]bool{'s': true, '-': true, '.': true, 'e': true, 'f': true, StateError: true}
@@ -63,12 +81,20 @@ const StateExponentStarted = byte('e')
 const StateExponentWithSign = byte('f')
 const StateExponentOngoing = byte('g')
 
+// JSON5 states, see ParseJSON5Number.
+const StateIntegerPositive = byte('+')
+const StateFractionLeading = byte(',')
+const StateHexStarted = byte('x')
+const StateHexOngoing = byte('h')
+const StateNonFinite = byte('i')
+
 const ActionNothing = 0
 const ActionIntMatinsa = 1      // if intdigits < maxMantDigits  { mantissa = (mantissa * 10) + uint64(c-'0') }; intdigits++
 const ActionFracMatinsa = 2     // if fracdigits+intdigits < maxMantDigits { mantissa = (mantissa * 10) + uint64(c-'0') }; fracdigits++
 const ActionSetSignNegative = 3 // sign = -1
 const ActionSetExponentSign = 4 // expsign = -1
 const ActionExpPart = 5         // if exppart < 10000 { exppart = (exppart * 10) + int(c-'0') }; expdigits++
+const ActionHexMantissa = 6     // mantissa = (mantissa * 16) + hexval(c); hexdigits++
 
 var machine = [256][256]dst{
 	// start
@@ -209,6 +235,43 @@ func init() {
 	}
 }
 
+// machine5 is the number state machine for JSON5, which extends the JSON number state machine with
+// a leading '+', leading and trailing decimal points and hexadecimal integers.
+var machine5 [256][256]dst
+
+// isFailState5 is isFailState for machine5, where a trailing decimal point is accepted.
+var isFailState5 = [256]bool{'s': true, '-': true, '+': true, ',': true, 'e': true, 'f': true, 'x': true, StateError: true}
+
+func init() {
+	// machine5 is initialized after machine, so that it starts with the default error states of machine.
+	machine5 = machine
+	for c := range 256 {
+		machine5[StateIntegerPositive][c] = dst{state: StateError}
+		machine5[StateFractionLeading][c] = dst{state: StateError}
+		machine5[StateHexStarted][c] = dst{state: StateError}
+		if machine5[StateFractionStarted][c].state == StateError {
+			// 1. is a number
+			machine5[StateFractionStarted][c] = dst{state: StateSuccess}
+		}
+	}
+	machine5[StateStart]['+'] = dst{StateIntegerPositive, ActionNothing}
+	machine5[StateStart]['.'] = dst{StateFractionLeading, ActionNothing}
+	machine5[StateIntegerNegative]['.'] = dst{StateFractionLeading, ActionNothing}
+	machine5[StateIntegerPositive]['.'] = dst{StateFractionLeading, ActionNothing}
+	for c := '0'; c <= '9'; c++ {
+		machine5[StateIntegerPositive][c] = machine[StateIntegerNegative][c]
+		machine5[StateFractionLeading][c] = machine[StateFractionStarted][c]
+	}
+	machine5[StateIntegerComplete]['x'] = dst{StateHexStarted, ActionNothing}
+	machine5[StateIntegerComplete]['X'] = dst{StateHexStarted, ActionNothing}
+	for c, ok := range hextable {
+		if ok {
+			machine5[StateHexStarted][c] = dst{StateHexOngoing, ActionHexMantissa}
+			machine5[StateHexOngoing][c] = dst{StateHexOngoing, ActionHexMantissa}
+		}
+	}
+}
+
 // Number returns the offset after the prefix of a valid number.
 // The number BNF:
 // number := integer fraction exponent
@@ -220,6 +283,37 @@ func init() {
 // exponent := "" | 'E' sign digits | 'e' sign digits
 // sign := "" | '+' | '-'
 func ParseNumber(buf []byte) (offset int, intres int64, intok bool, floatres float64, floatok bool, decimalok bool) {
+	offset, intres, intok, _, _, floatres, floatok, decimalok = parseNumberWith(&machine, &isFailState, buf, false)
+	return
+}
+
+// ParseNumberUint is the same as ParseNumber, ParseNonFiniteNumber or ParseJSON5Number, depending on the mode,
+// except that integers between math.MaxInt64+1 and math.MaxUint64 are returned as uint64,
+// instead of as a decimal or, for hexadecimal integers, as a float.
+func ParseNumberUint(buf []byte, mode Mode) (offset int, intres int64, intok bool, uintres uint64, uintok bool, floatres float64, floatok bool, decimalok bool) {
+	if mode&JSON5 != 0 {
+		if n, f, state := nonFinite(buf); state != StateStart {
+			if state == StateNonFinite {
+				return n, 0, false, 0, false, f, true, false
+			}
+			return
+		}
+		return parseNumberWith(&machine5, &isFailState5, buf, true)
+	}
+	if mode&NonFinite != 0 {
+		if n, f, state := nonFiniteJSON(buf); state != StateStart {
+			if state == StateNonFinite {
+				return n, 0, false, 0, false, f, true, false
+			}
+			return
+		}
+	}
+	return parseNumberWith(&machine, &isFailState, buf, true)
+}
+
+// parseNumberWith is the same as ParseNumber, but for the given state machine and fail states.
+// Only if uints is true, integers that are too large for an int64, but fit into a uint64, are returned as uint64.
+func parseNumberWith(machine *[256][256]dst, isFailState *[256]bool, buf []byte, uints bool) (offset int, intres int64, intok bool, uintres uint64, uintok bool, floatres float64, floatok bool, decimalok bool) {
 	sign := int64(1)
 	expsign := 1
 	var mantissa uint64
@@ -227,6 +321,12 @@ func ParseNumber(buf []byte) (offset int, intres int64, intok bool, floatres flo
 	var fracdigits int
 	var exppart int
 	var expdigits int
+	var hexdigits int
+	var hexoverflow bool
+	var hexfloat float64
+	// wide is the mantissa of an integer with one more digit than maxMantDigits, which might still fit in a uint64.
+	var wide uint64
+	var wideok bool
 	maxMantDigits := 19 // 10^19 fits in uint64
 	state := StateStart // start
 	for _, c := range buf {
@@ -237,6 +337,11 @@ func ParseNumber(buf []byte) (offset int, intres int64, intok bool, floatres flo
 		case ActionIntMatinsa:
 			if intdigits < maxMantDigits {
 				mantissa = (mantissa * 10) + uint64(c-'0')
+			} else if intdigits == maxMantDigits {
+				hi, lo := bits.Mul64(mantissa, 10)
+				var carry uint64
+				wide, carry = bits.Add64(lo, uint64(c-'0'), 0)
+				wideok = hi == 0 && carry == 0
 			}
 			intdigits++
 		case ActionFracMatinsa:
@@ -253,6 +358,14 @@ func ParseNumber(buf []byte) (offset int, intres int64, intok bool, floatres flo
 				exppart = (exppart * 10) + int(c-'0')
 			}
 			expdigits++
+		case ActionHexMantissa:
+			v := uint64(hexval(c))
+			if mantissa>>60 != 0 {
+				hexoverflow = true
+			}
+			mantissa = (mantissa << 4) | v
+			hexfloat = (hexfloat * 16) + float64(v)
+			hexdigits++
 		}
 		if state == StateError || state == StateSuccess {
 			break
@@ -264,18 +377,46 @@ func ParseNumber(buf []byte) (offset int, intres int64, intok bool, floatres flo
 	}
 
 	neg := sign == -1
-	// It is an int
-	if expdigits == 0 && fracdigits == 0 {
+	cutOffInt64 := uint64(1 << uint(64-1))
+	// It is a hexadecimal integer
+	if hexdigits > 0 {
+		if !hexoverflow && ((!neg && mantissa < cutOffInt64) || (neg && mantissa <= cutOffInt64)) {
+			intres = sign * int64(mantissa)
+			intok = true
+			return
+		}
+		if uints && !hexoverflow && !neg {
+			uintres = mantissa
+			uintok = true
+			return
+		}
+		// It is too large for an int, so it is approximated by a float
+		floatres = float64(sign) * hexfloat
+		floatok = true
+		return
+	}
+	// It is an int, unless it is a JSON5 number with a trailing decimal point
+	if expdigits == 0 && fracdigits == 0 && buf[offset-1] != '.' {
 		if intdigits > maxMantDigits {
+			if uints && !neg && intdigits == maxMantDigits+1 && wideok {
+				// It uses as many digits as MaxUint64 and still fits
+				uintres = wide
+				uintok = true
+				return
+			}
 			// It uses more digits than MaxInt64 and MinInt64, so it is decimal
 			decimalok = true
 			return
 		}
-		cutOffInt64 := uint64(1 << uint(64-1))
 		if (!neg && mantissa < cutOffInt64) || (neg && mantissa <= cutOffInt64) {
 			intres = sign * int64(mantissa)
 			intok = true
 			return
+		} else if uints && !neg {
+			// It is larger than an int, but 19 digits always fit in a uint64
+			uintres = mantissa
+			uintok = true
+			return
 		} else {
 			// It is larger than an int, so it must be decimal
 			decimalok = true
@@ -312,3 +453,129 @@ func ParseNumber(buf []byte) (offset int, intres int64, intok bool, floatres flo
 	decimalok = true
 	return
 }
+
+// NonFiniteNumber is the same as Number, except that it also accepts NaN, Infinity and -Infinity,
+// as they are written by Python's json.dumps.
+func NonFiniteNumber(buf []byte) (int, error) {
+	offset, state := nonFiniteNumber(buf)
+	if isFailState[state] {
+		return 0, errScanNumber
+	}
+	return offset, nil
+}
+
+// nonFiniteNumber is the same as number, but also for NaN, Infinity and -Infinity.
+func nonFiniteNumber(buf []byte) (int, byte) {
+	if offset, _, state := nonFiniteJSON(buf); state != StateStart {
+		return offset, state
+	}
+	return number(buf)
+}
+
+// ParseNonFiniteNumber is the same as ParseNumber, except that it also parses NaN, Infinity and -Infinity as floats.
+func ParseNonFiniteNumber(buf []byte) (offset int, intres int64, intok bool, floatres float64, floatok bool, decimalok bool) {
+	if n, f, state := nonFiniteJSON(buf); state != StateStart {
+		if state == StateNonFinite {
+			return n, 0, false, f, true, false
+		}
+		return
+	}
+	return ParseNumber(buf)
+}
+
+// nonFiniteJSON is the same as nonFinite, except that it only accepts NaN, Infinity and -Infinity.
+// The other signs are only valid in JSON5.
+func nonFiniteJSON(buf []byte) (int, float64, byte) {
+	n, f, state := nonFinite(buf)
+	if state == StateStart || buf[0] != '+' && !(buf[0] == '-' && len(buf) > 1 && buf[1] == 'N') {
+		return n, f, state
+	}
+	return 0, 0, StateError
+}
+
+// JSON5Number is the same as Number, except that it accepts JSON5 numbers:
+// a leading '+', leading and trailing decimal points, hexadecimal integers, Infinity and NaN.
+func JSON5Number(buf []byte) (int, error) {
+	offset, state := number5(buf)
+	if isFailState5[state] {
+		return 0, errScanNumber
+	}
+	return offset, nil
+}
+
+// number5 is the same as number, but for JSON5 numbers.
+func number5(buf []byte) (int, byte) {
+	if offset, _, state := nonFinite(buf); state != StateStart {
+		return offset, state
+	}
+	return numberWith(&machine5, buf)
+}
+
+// ParseJSON5Number is the same as ParseNumber, except that it parses JSON5 numbers, see JSON5Number.
+// Hexadecimal integers that do not fit into an int64, Infinity and NaN are returned as floats.
+func ParseJSON5Number(buf []byte) (offset int, intres int64, intok bool, floatres float64, floatok bool, decimalok bool) {
+	if n, f, state := nonFinite(buf); state != StateStart {
+		if state == StateNonFinite {
+			return n, 0, false, f, true, false
+		}
+		return
+	}
+	offset, intres, intok, _, _, floatres, floatok, decimalok = parseNumberWith(&machine5, &isFailState5, buf, false)
+	return
+}
+
+var infinityBytes = []byte("Infinity")
+
+var nanBytes = []byte("NaN")
+
+// nonFinite parses an optionally signed Infinity or NaN.
+// It returns StateStart if the number is not Infinity or NaN, StateNonFinite if it is,
+// and StateError, with the offset at the end of the buffer, if it might still become Infinity or NaN.
+func nonFinite(buf []byte) (int, float64, byte) {
+	i := 0
+	if len(buf) > 0 && (buf[0] == '+' || buf[0] == '-') {
+		i = 1
+	}
+	if i == len(buf) || (buf[i] != 'I' && buf[i] != 'N') {
+		return 0, 0, StateStart
+	}
+	word, value := infinityBytes, math.Inf(1)
+	if buf[i] == 'N' {
+		word, value = nanBytes, math.NaN()
+	}
+	rest := buf[i:]
+	if len(rest) < len(word) {
+		if bytes.Equal(rest, word[:len(rest)]) {
+			return len(buf), 0, StateError
+		}
+		return 0, 0, StateError
+	}
+	if !bytes.Equal(rest[:len(word)], word) {
+		return 0, 0, StateError
+	}
+	if buf[0] == '-' {
+		value = -value
+	}
+	return i + len(word), value, StateNonFinite
+}
+
+// maxSafeInteger is the largest integer that can be represented exactly by an IEEE 754 double, (2^53)-1.
+const maxSafeInteger = "9007199254740991"
+
+// safeInteger returns false if the number is an integer outside of the range [-(2^53)+1, (2^53)-1].
+// Numbers with a fraction or an exponent are not integers.
+func safeInteger(number []byte) bool {
+	if len(number) > 0 && number[0] == '-' {
+		number = number[1:]
+	}
+	for _, c := range number {
+		if c < '0' || c > '9' {
+			return true
+		}
+	}
+	if len(number) != len(maxSafeInteger) {
+		return len(number) < len(maxSafeInteger)
+	}
+	// JSON integers do not have leading zeros, so integers of the same length can be compared as strings.
+	return string(number) <= maxSafeInteger
+}
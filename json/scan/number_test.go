@@ -16,6 +16,7 @@ package scan
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/katydid/parser-go-json/json/internal/fork/strconv"
@@ -147,3 +148,141 @@ func checkNumber(token []byte) error {
 	}
 	return nil
 }
+
+func TestParseNumberUint(t *testing.T) {
+	uints := map[string]uint64{
+		"9223372036854775808":  math.MaxInt64 + 1,
+		"9999999999999999999":  9999999999999999999,
+		"10000000000000000000": 1e19,
+		"18446744073709551615": math.MaxUint64,
+	}
+	for input, want := range uints {
+		offset, _, intok, got, ok, _, _, decimalok := ParseNumberUint([]byte(input), 0)
+		if !ok || intok || decimalok || got != want || offset != len(input) {
+			t.Fatalf("%s: want uint %d, but got %d, %v", input, want, got, ok)
+		}
+		if _, _, _, _, _, decimalok := ParseNumber([]byte(input)); !decimalok {
+			t.Fatalf("%s: want decimal from ParseNumber", input)
+		}
+	}
+	decimals := []string{
+		"18446744073709551616", // math.MaxUint64 + 1
+		"18446744073709551620",
+		"99999999999999999999",
+		"100000000000000000000",
+		"-9223372036854775809",
+	}
+	for _, input := range decimals {
+		if _, _, _, _, uintok, _, _, decimalok := ParseNumberUint([]byte(input), 0); uintok || !decimalok {
+			t.Fatalf("%s: want decimal", input)
+		}
+	}
+	hexes := map[string]uint64{
+		"0x8000000000000000": 1 << 63,
+		"0xFFFFFFFFFFFFFFFF": math.MaxUint64,
+	}
+	for input, want := range hexes {
+		_, _, _, got, ok, _, _, _ := ParseNumberUint([]byte(input), JSON5)
+		if !ok || got != want {
+			t.Fatalf("%s: want uint %d, but got %d, %v", input, want, got, ok)
+		}
+	}
+	if _, _, _, _, _, got, ok, _ := ParseNumberUint([]byte("-0x8000000000000001"), JSON5); !ok || got != -(1<<63) {
+		t.Fatalf("want float %v, but got %v, %v", -(1 << 63), got, ok)
+	}
+	if _, _, _, _, _, got, ok, _ := ParseNumberUint([]byte("-Infinity"), NonFinite); !ok || !math.IsInf(got, -1) {
+		t.Fatalf("want -Infinity, but got %v, %v", got, ok)
+	}
+}
+
+func TestSafeInteger(t *testing.T) {
+	safe := map[string]bool{
+		"0":                    true,
+		"-1":                   true,
+		"9007199254740991":     true,
+		"-9007199254740991":    true,
+		"9007199254740992":     false,
+		"-9007199254740992":    false,
+		"10000000000000000":    false,
+		"999999999999999":      true,
+		"9007199254740993.0":   true,
+		"1e300":                true,
+		"18446744073709551615": false,
+	}
+	for input, want := range safe {
+		t.Run(input, func(t *testing.T) {
+			if got := safeInteger([]byte(input)); got != want {
+				t.Fatalf("want %v, but got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestNonFiniteNumber(t *testing.T) {
+	valid := map[string]int{
+		"NaN":        3,
+		"Infinity":   8,
+		"-Infinity,": 9,
+		"1.5":        3,
+		"-1]":        2,
+	}
+	invalid := []string{
+		"-NaN",
+		"+Infinity",
+		"+1",
+		"Inf",
+		"nan",
+		"infinity",
+	}
+	for input, want := range valid {
+		got, err := NonFiniteNumber([]byte(input))
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("%s: offset want %d, but got %d", input, want, got)
+		}
+	}
+	for _, input := range invalid {
+		if _, err := NonFiniteNumber([]byte(input)); err == nil {
+			t.Fatalf("%s: expected error", input)
+		}
+		if _, _, intok, _, floatok, decimalok := ParseNonFiniteNumber([]byte(input)); intok || floatok || decimalok {
+			t.Fatalf("%s: expected parse error", input)
+		}
+	}
+	floats := map[string]float64{
+		"Infinity":  math.Inf(1),
+		"-Infinity": math.Inf(-1),
+		"1.5":       1.5,
+	}
+	for input, want := range floats {
+		_, _, _, got, ok, _ := ParseNonFiniteNumber([]byte(input))
+		if !ok || got != want {
+			t.Fatalf("%s: want %v, but got %v", input, want, got)
+		}
+	}
+	_, _, _, got, ok, _ := ParseNonFiniteNumber([]byte("NaN"))
+	if !ok || !math.IsNaN(got) {
+		t.Fatalf("want NaN, but got %v", got)
+	}
+}
+
+func TestNonFiniteScanner(t *testing.T) {
+	s := NewScanner([]byte("[NaN, -Infinity, Infinity]"))
+	s.SetMode(NonFinite)
+	want := []string{"[", "NaN", ",", "-Infinity", ",", "Infinity", "]"}
+	for _, w := range want {
+		_, token, err := Next(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(token) != w {
+			t.Fatalf("want %s, but got %s", w, token)
+		}
+	}
+	s = NewScanner([]byte("NaN"))
+	if kind, _, _ := Next(s); kind != UnknownKind {
+		t.Fatalf("expected NaN to be unknown in JSON, but got %v", kind)
+	}
+}
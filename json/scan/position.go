@@ -0,0 +1,103 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// Position is the location of a token in the input.
+type Position struct {
+	// Offset is the number of bytes before the token, starting at 0.
+	Offset int
+	// Line is the line number, starting at 1.
+	Line int
+	// Column is the number of bytes from the start of the line, starting at 1.
+	Column int
+}
+
+func (p Position) String() string {
+	return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
+
+// lines keeps track of how far the newlines in the input have been counted.
+type lines struct {
+	// counted is the offset in the input up to which newlines have been counted.
+	counted int
+	// newlines is the number of newlines before counted.
+	newlines int
+	// lineStart is the offset in the input of the start of the line that contains counted.
+	lineStart int
+}
+
+func (s *scanner) resetPosition() {
+	s.discarded = 0
+	s.start = 0
+	s.lines = lines{}
+	s.startLines = lines{}
+}
+
+// Offset returns the offset in bytes of the current token from the start of the input.
+func (s *scanner) Offset() int {
+	return s.start
+}
+
+// Position returns the offset, line and column of the current token.
+// Newlines are only counted from where the previous call to Position stopped,
+// so that calling Position for every token is still linear in the size of the input.
+func (s *scanner) Position() Position {
+	l := s.startLines
+	if s.start >= s.lines.counted {
+		s.countLines(s.start)
+		l = s.lines
+	}
+	return Position{Offset: s.start, Line: l.newlines + 1, Column: s.start - l.lineStart + 1}
+}
+
+// countLines counts the newlines in the buffer up to the given offset in the input.
+func (s *scanner) countLines(to int) {
+	if to <= s.lines.counted {
+		return
+	}
+	from := s.lines.counted
+	buf := s.buf[from-s.discarded : to-s.discarded]
+	for {
+		i := bytes.IndexByte(buf, '\n')
+		if i < 0 {
+			break
+		}
+		s.lines.newlines++
+		from += i + 1
+		s.lines.lineStart = from
+		buf = buf[i+1:]
+	}
+	s.lines.counted = to
+}
+
+// slide is called before the first n bytes of the buffer are discarded,
+// to count the newlines in those bytes and to move the offsets that are relative to the buffer.
+func (s *scanner) slide(n int) {
+	end := s.discarded + n
+	if s.start < end && s.start >= s.lines.counted {
+		// The current token is discarded, so remember its position.
+		s.countLines(s.start)
+		s.startLines = s.lines
+	}
+	s.countLines(end)
+	s.discarded = end
+	s.recordEnd -= n
+	s.recordNext -= n
+}
@@ -0,0 +1,149 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go-json/json/rand"
+)
+
+// naivePosition counts the lines and columns from the start of the input.
+func naivePosition(input []byte, offset int) Position {
+	line := 1 + bytes.Count(input[:offset], []byte("\n"))
+	lineStart := bytes.LastIndexByte(input[:offset], '\n') + 1
+	return Position{Offset: offset, Line: line, Column: offset - lineStart + 1}
+}
+
+func expectPositions(t *testing.T, input []byte, s Scanner, next func() (Kind, []byte, error)) {
+	t.Helper()
+	want := NewScanner(input)
+	for {
+		_, _, wantErr := Next(want)
+		_, _, gotErr := next()
+		if wantErr != gotErr {
+			t.Fatalf("want error %v, but got %v", wantErr, gotErr)
+		}
+		if wantErr != nil {
+			return
+		}
+		if want.Offset() != s.Offset() {
+			t.Fatalf("want offset %d, but got %d", want.Offset(), s.Offset())
+		}
+		wantPos := naivePosition(input, want.Offset())
+		if gotPos := s.Position(); gotPos != wantPos {
+			t.Fatalf("want position %v, but got %v", wantPos, gotPos)
+		}
+	}
+}
+
+func TestPositionExample(t *testing.T) {
+	s := NewScanner([]byte("{\n  \"a\": [1,\n\t2]\n}"))
+	want := []Position{
+		{Offset: 0, Line: 1, Column: 1},
+		{Offset: 4, Line: 2, Column: 3},
+		{Offset: 7, Line: 2, Column: 6},
+		{Offset: 9, Line: 2, Column: 8},
+		{Offset: 10, Line: 2, Column: 9},
+		{Offset: 11, Line: 2, Column: 10},
+		{Offset: 14, Line: 3, Column: 2},
+		{Offset: 15, Line: 3, Column: 3},
+		{Offset: 17, Line: 4, Column: 1},
+	}
+	for _, w := range want {
+		if _, _, err := Next(s); err != nil {
+			t.Fatal(err)
+		}
+		if got := s.Position(); got != w {
+			t.Fatalf("want position %v, but got %v", w, got)
+		}
+	}
+	if _, _, err := Next(s); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
+
+// multilineInput joins random values into one large array, that does not fit into the default window.
+func multilineInput() []byte {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	input := []byte("[\n")
+	for i, value := range values {
+		if i > 0 {
+			input = append(input, ",\n"...)
+		}
+		input = append(input, value...)
+	}
+	return append(input, "\n]"...)
+}
+
+func TestPositionInit(t *testing.T) {
+	input := multilineInput()
+	s := NewScanner(input)
+	expectPositions(t, input, s, func() (Kind, []byte, error) { return Next(s) })
+}
+
+func TestPositionReader(t *testing.T) {
+	input := multilineInput()
+	if len(input) < 2*defaultWindowSize {
+		t.Fatalf("expected input to be larger than the window, but got %d bytes", len(input))
+	}
+	s := NewReaderScanner(iotest.OneByteReader(bytes.NewReader(input)))
+	expectPositions(t, input, s, func() (Kind, []byte, error) { return Next(s) })
+}
+
+func TestPositionFeed(t *testing.T) {
+	input := multilineInput()
+	s := NewScanner(nil)
+	s.Feed(nil)
+	fed := 0
+	expectPositions(t, input, s, func() (Kind, []byte, error) { return nextFed(s, input, &fed) })
+}
+
+func TestPositionFeedAfterInit(t *testing.T) {
+	s := NewScanner([]byte("[\n1"))
+	expect(t, next(s), ArrayOpenKind)
+	// Feed copies the rest of the buffer that was passed to Init, which discards the current token.
+	s.Feed([]byte(",2]"))
+	if got, want := s.Position(), (Position{Offset: 0, Line: 1, Column: 1}); got != want {
+		t.Fatalf("want position %v, but got %v", want, got)
+	}
+	expect(t, next(s), NumberKind)
+	if got, want := s.Position(), (Position{Offset: 2, Line: 2, Column: 1}); got != want {
+		t.Fatalf("want position %v, but got %v", want, got)
+	}
+}
+
+func TestPositionNoAllocsAfterWarmUp(t *testing.T) {
+	r := bytes.NewReader(nil)
+	s := NewReaderScanner(r)
+	testrun.NoAllocsOnAverage(t, func(input []byte) {
+		r.Reset(input)
+		s.InitReader(r)
+		for {
+			if _, _, err := Next(s); err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				return
+			}
+			_ = s.Position()
+		}
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 Walter Schulze
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	"github.com/katydid/parser-go-json/json/rand"
+)
+
+func expectSameTokens(t *testing.T, want, got Scanner) {
+	t.Helper()
+	for {
+		wantKind, wantToken, wantErr := Next(want)
+		gotKind, gotToken, gotErr := Next(got)
+		if wantErr != gotErr {
+			t.Fatalf("want error %v, but got %v", wantErr, gotErr)
+		}
+		if wantErr != nil {
+			return
+		}
+		if wantKind != gotKind {
+			t.Fatalf("want kind %v, but got %v", wantKind, gotKind)
+		}
+		if !bytes.Equal(wantToken, gotToken) {
+			t.Fatalf("want token %s, but got %s", wantToken, gotToken)
+		}
+	}
+}
+
+func TestReaderRandomScan(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	for _, value := range values {
+		name := testrun.Name(value)
+		t.Run(name, func(t *testing.T) {
+			want := NewScanner(value)
+			got := NewReaderScanner(iotest.OneByteReader(bytes.NewReader(value)))
+			expectSameTokens(t, want, got)
+		})
+	}
+}
+
+func TestReaderStraddlingTokens(t *testing.T) {
+	inputs := []string{
+		`123`,
+		`[123,-1.5e+10]`,
+		`"abc"`,
+		`"é\"\\"`,
+		`[true,false,null]`,
+		`{"` + strings.Repeat("a", 3*defaultWindowSize) + `":1}`,
+	}
+	for _, input := range inputs {
+		t.Run(testrun.Name([]byte(input)), func(t *testing.T) {
+			want := NewScanner([]byte(input))
+			got := NewReaderScanner(iotest.OneByteReader(strings.NewReader(input)))
+			expectSameTokens(t, want, got)
+		})
+	}
+}
+
+func TestReaderInvalidToken(t *testing.T) {
+	s := NewReaderScanner(iotest.OneByteReader(strings.NewReader(`tru`)))
+	if _, _, err := Next(s); err == nil || err == io.EOF {
+		t.Fatalf("expected error, but got %v", err)
+	}
+}
+
+func TestReaderError(t *testing.T) {
+	s := NewReaderScanner(iotest.ErrReader(io.ErrClosedPipe))
+	if _, _, err := Next(s); err != io.ErrClosedPipe {
+		t.Fatalf("expected reader error, but got %v", err)
+	}
+}
+
+func TestReaderNoAllocsAfterWarmUp(t *testing.T) {
+	r := bytes.NewReader(nil)
+	s := NewReaderScanner(r)
+	testrun.NoAllocsOnAverage(t, func(input []byte) {
+		r.Reset(input)
+		s.InitReader(r)
+		if err := walk(s); err != nil {
+			t.Fatalf("expected EOF, but got %v", err)
+		}
+	})
+}
@@ -58,6 +58,41 @@ func NextEnd(kind Kind, buf []byte, offset int) (int, error) {
 	return offset, nil
 }
 
+// nextEnd is the same as NextEnd, except that it returns io.ErrUnexpectedEOF,
+// if the token could continue after the end of the buffer.
+func nextEnd(kind Kind, buf []byte, offset int) (int, error) {
+	switch kind {
+	case StringKind:
+		n, err := stringEnd(buf[offset:])
+		if err != nil {
+			return 0, err
+		}
+		return incOffset(buf, offset, n)
+	case NumberKind:
+		return numberEnd(buf, offset, number, &isFailState)
+	case TrueKind:
+		return constEnd(buf, offset, trueBytes, errExpectedTrue)
+	case FalseKind:
+		return constEnd(buf, offset, falseBytes, errExpectedFalse)
+	case NullKind:
+		return constEnd(buf, offset, nullBytes, errExpectedNull)
+	}
+	return NextEnd(kind, buf, offset)
+}
+
+// numberEnd returns the end offset of the number, which is scanned using the given number function,
+// or io.ErrUnexpectedEOF if the number could continue after the end of the buffer.
+func numberEnd(buf []byte, offset int, number func([]byte) (int, byte), isFailState *[256]bool) (int, error) {
+	n, state := number(buf[offset:])
+	if offset+n == len(buf) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if isFailState[state] {
+		return 0, errScanNumber
+	}
+	return incOffset(buf, offset, n)
+}
+
 var errUnknownKind = errors.New("unknown kind")
 
 // looking up in an array is faster than a map.
@@ -125,6 +160,34 @@ func scanString(buf []byte, offset int) (int, error) {
 	return incOffset(buf, offset, n)
 }
 
+func scanStrictString(buf []byte, offset int) (int, error) {
+	n, err := StrictString(buf[offset:])
+	if err != nil {
+		return 0, err
+	}
+	return incOffset(buf, offset, n)
+}
+
+func scanNonFiniteNumber(buf []byte, offset int) (int, error) {
+	n, err := NonFiniteNumber(buf[offset:])
+	if err != nil {
+		return 0, err
+	}
+	return incOffset(buf, offset, n)
+}
+
+// scanSafeInteger scans the number using the scan function and then checks that it is a safe integer.
+func scanSafeInteger(buf []byte, offset int, scan func([]byte, int) (int, error)) (int, error) {
+	end, err := scan(buf, offset)
+	if err != nil {
+		return 0, err
+	}
+	if !safeInteger(buf[offset:end]) {
+		return 0, ErrUnsafeInteger
+	}
+	return end, nil
+}
+
 func skipSpace(buf []byte, offset int) (int, error) {
 	if offset >= len(buf) {
 		return offset, nil
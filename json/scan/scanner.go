@@ -14,7 +14,10 @@
 
 package scan
 
-import "io"
+import (
+	"bytes"
+	"io"
+)
 
 func Next(s Scanner) (Kind, []byte, error) {
 	k, _, err := s.NextStart()
@@ -29,6 +32,26 @@ type Scanner interface {
 	// Init restarts the scanner with a new byte buffer, without allocating a new scanner.
 	Init([]byte)
 
+	// InitReader restarts the scanner with a reader, without allocating a new scanner.
+	// The reader is read into a sliding window buffer, which is reused on the next call to InitReader.
+	// Byte slices returned by the scanner are only valid until the next call to NextStart.
+	InitReader(io.Reader)
+
+	// Feed appends a chunk of input to the scanner, which is copied into a sliding window buffer.
+	// Until Close is called, NextStart returns ErrNeedMoreInput instead of io.EOF,
+	// when it reaches the end of the input or a token that is cut off at the end of the input.
+	// Calling NextStart again, after feeding more input, resumes exactly where it stopped.
+	// Byte slices returned by the scanner are only valid until the next call to NextStart.
+	Feed([]byte)
+	// Close signals that no more input will be fed to the scanner.
+	Close()
+
+	// NextRecord restricts scanning to the next record, which ends just before the next sep byte or at the end of the input.
+	// Whatever is left of the current record is skipped.
+	// After the end of the record, NextStart returns io.EOF, until NextRecord is called again.
+	// NextRecord returns true, if the record only contains spaces, and io.EOF if there are no more records.
+	NextRecord(sep byte) (bool, error)
+
 	// NextStart skips to the start of the next token and returns a byte slice that start at that token.
 	// Following that the client needs to either:
 	//   * call ScanToEnd to automatically Scan to the end of the that token and get the slice that contains only that token.
@@ -36,40 +59,339 @@ type Scanner interface {
 	NextStart() (Kind, []byte, error)
 	ScanToEnd(Kind) ([]byte, error)
 	Skip(offset int) error
+
+	// Offset returns the offset in bytes of the current token from the start of the input.
+	Offset() int
+	// Position returns the offset, line and column of the current token.
+	// The line and column are only calculated when Position is called.
+	Position() Position
+	// Mark marks the start of the current token, so that it is kept in the buffer until Marked or Unmark is called.
+	Mark()
+	// Marked returns the input from the start of the marked token up to the offset and removes the mark.
+	Marked() []byte
+	// Unmark removes the mark.
+	Unmark()
+
+	// Limit sets the limits of the size of the input and of its tokens.
+	// ScanToEnd returns ErrStringTooLong or ErrNumberTooLong, wrapped in a *SyntaxError, if the token exceeds its limit.
+	// NextStart returns ErrInputTooLarge once more input has been read, fed or initialized than is allowed.
+	Limit(Limits)
+	// SetMode sets the mode of the scanner, for example StrictUTF8.
+	SetMode(Mode)
+	// Comment returns the comments before the current token, if the mode allows comments,
+	// from the start of the first comment up to the end of the last comment, including the whitespace between them.
+	// It returns nil if there are no comments before the current token.
+	// The returned slice is only valid until the next call to NextStart.
+	Comment() []byte
+
+	// SyntaxError returns a *SyntaxError that wraps the error, with the position of the current token and an excerpt of the input around it.
+	SyntaxError(err error, found Kind, expected []Kind) error
 }
 
 type scanner struct {
 	buf    []byte
 	offset int
+
+	// reader is only set if the scanner was initialized with InitReader.
+	reader io.Reader
+	// more is true while the reader has not yet returned io.EOF or until Close is called.
+	more bool
+	// window is the buffer that the reader reads into or that Feed appends to.
+	// It is kept separately from buf, so that it can be reused on the next call to InitReader.
+	window []byte
+	// windowed is true if buf is a slice of window and not a buffer that was passed to Init.
+	windowed bool
+
+	// record is true if scanning is restricted to a record, see NextRecord.
+	record bool
+	// recordEnd is the offset of the separator at the end of the current record.
+	recordEnd int
+	// recordNext is the offset where the next record starts.
+	recordNext int
+	// recordStart is the offset in the input where the current record starts, which is not moved when the window slides.
+	recordStart int
+
+	// discarded is the number of bytes of the input that were discarded from the front of the window.
+	discarded int
+	// start is the offset in the input of the current token.
+	start int
+	// lines is how far the newlines in the input have been counted, see Position.
+	lines lines
+	// startLines is a snapshot of lines at the start of the current token, for when the token was discarded.
+	startLines lines
+
+	// marked is true if the input from mark onwards may not be discarded, see Mark.
+	marked bool
+	// mark is the offset in the input of the marked token.
+	mark int
+
+	limits Limits
+	mode   Mode
+	// comments are the comments before the current token, which are kept in the buffer until the next token.
+	comments comments
 }
 
+// defaultWindowSize is the initial size of the sliding window buffer, which grows if a single token does not fit into it.
+const defaultWindowSize = 4096
+
 // NewScanner returns a Scanner which keeps track of the buffer and the offset.
+// If buf is nil, the scanner waits for input to be fed, so NextStart returns ErrNeedMoreInput until Close is called.
 func NewScanner(buf []byte) Scanner {
 	return &scanner{
 		buf:    buf,
 		offset: 0,
+		more:   buf == nil,
 	}
 }
 
+// NewReaderScanner returns a Scanner which reads from the reader into a sliding window buffer.
+func NewReaderScanner(r io.Reader) Scanner {
+	s := &scanner{}
+	s.InitReader(r)
+	return s
+}
+
 // Init restarts the scanner with a new byte buffer, without allocating a new scanner.
 func (s *scanner) Init(buf []byte) {
 	s.buf = buf
 	s.offset = 0
+	s.reader = nil
+	s.more = false
+	s.windowed = false
+	s.record = false
+	s.marked = false
+	s.comments = comments{}
+	s.resetPosition()
+}
+
+// InitReader restarts the scanner with a reader, without allocating a new scanner.
+func (s *scanner) InitReader(r io.Reader) {
+	if s.window == nil {
+		s.window = make([]byte, 0, defaultWindowSize)
+	}
+	s.buf = s.window[:0]
+	s.offset = 0
+	s.reader = r
+	s.more = true
+	s.windowed = true
+	s.record = false
+	s.marked = false
+	s.comments = comments{}
+	s.resetPosition()
+}
+
+// Feed appends a chunk of input to the scanner.
+func (s *scanner) Feed(chunk []byte) {
+	if !s.windowed {
+		// Copy the rest of the buffer that was passed to Init, so that we never append to the caller's buffer.
+		keep := s.keep()
+		s.slide(keep)
+		s.buf = append(s.window[:0], s.buf[keep:]...)
+		s.offset -= keep
+		s.windowed = true
+	}
+	// Append does not modify the bytes that are already in the buffer,
+	// so that the token that was returned by NextStart is still valid.
+	s.buf = append(s.buf, chunk...)
+	s.window = s.buf[:0]
+	s.more = true
+}
+
+// Close signals that no more input will be fed to the scanner.
+func (s *scanner) Close() {
+	s.more = false
+}
+
+// scannable returns the part of the buffer that can be scanned, which is restricted to the current record.
+func (s *scanner) scannable() []byte {
+	if s.record {
+		return s.buf[:s.recordEnd]
+	}
+	return s.buf
 }
 
 func (s *scanner) NextStart() (Kind, []byte, error) {
-	kind, start, err := NextStart(s.buf, s.offset)
+	if s.comments.token {
+		// The comments belonged to the previous token.
+		s.comments = comments{}
+	}
+	if err := s.checkInput(); err != nil {
+		return UnknownKind, nil, err
+	}
+	if s.mode&SkipBOM != 0 && s.discarded+s.offset == 0 {
+		if err := s.skipBOM(); err != nil {
+			return UnknownKind, nil, err
+		}
+	}
+	if s.more && !s.record {
+		return s.nextStartMore()
+	}
+	buf := s.scannable()
+	kind, start, err := s.nextStart(buf, s.offset)
 	if err != nil {
 		return kind, nil, err
 	}
-	buf := s.buf[start:]
 	s.offset = start
-	return kind, buf, nil
+	s.start = s.discarded + start
+	s.comments.token = true
+	return kind, buf[start:], nil
+}
+
+// NextRecord restricts scanning to the next record.
+func (s *scanner) NextRecord(sep byte) (bool, error) {
+	if s.record {
+		// Skip over whatever is left of the current record.
+		s.offset = s.recordNext
+		s.record = false
+	}
+	if s.more && s.reader == nil && s.offset > cap(s.buf)/2 {
+		// Discard the previous records, so that the buffer does not keep on growing while input is fed.
+		s.discard()
+	}
+	// searched is the number of bytes after the offset that have already been searched for the separator.
+	searched := 0
+	for {
+		if i := bytes.IndexByte(s.buf[s.offset+searched:], sep); i >= 0 {
+			s.recordEnd = s.offset + searched + i
+			s.recordNext = s.recordEnd + 1
+			break
+		}
+		searched = len(s.buf) - s.offset
+		if !s.more {
+			if searched == 0 {
+				return false, io.EOF
+			}
+			// The last record does not have to end with a separator.
+			s.recordEnd = len(s.buf)
+			s.recordNext = len(s.buf)
+			break
+		}
+		if err := s.fill(); err != nil {
+			return false, err
+		}
+	}
+	s.record = true
+	s.recordStart = s.offset + s.discarded
+	record := s.buf[s.offset:s.recordEnd]
+	return Space(record) == len(record), nil
+}
+
+// nextStartMore is NextStart for when more input can still be read.
+// It makes sure that the whole token is in the buffer,
+// so that the client never sees a token that straddles the end of the window.
+func (s *scanner) nextStartMore() (Kind, []byte, error) {
+	if s.reader == nil && s.offset > cap(s.buf)/2 {
+		// Discard the input that has been scanned, so that the buffer does not keep on growing while input is fed.
+		s.discard()
+	}
+	for {
+		kind, start, err := s.nextStart(s.buf, s.offset)
+		s.offset = start
+		if err == io.EOF && s.more {
+			// Only spaces were left in the buffer.
+			if err := s.fill(); err != nil {
+				return UnknownKind, nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return kind, nil, err
+		}
+		if _, err := s.nextEnd(kind, s.buf, start); err == io.ErrUnexpectedEOF && s.more {
+			// The token straddles the end of the window.
+			// Do not read more of a token that is already too long.
+			s.start = s.discarded + start
+			if err := s.checkToken(kind, s.buf[start:], true); err != nil {
+				return UnknownKind, nil, err
+			}
+			if err := s.fill(); err != nil {
+				return UnknownKind, nil, err
+			}
+			continue
+		}
+		s.start = s.discarded + start
+		s.comments.token = true
+		return kind, s.buf[start:], nil
+	}
+}
+
+// maxEmptyReads is the number of times a reader may return no bytes and no error, before we give up.
+const maxEmptyReads = 100
+
+// discard slides the window, so that the bytes before the offset, or before the mark, are discarded.
+func (s *scanner) discard() {
+	keep := s.keep()
+	s.slide(keep)
+	n := copy(s.window[:cap(s.window)], s.buf[keep:])
+	s.buf = s.window[:n]
+	s.offset -= keep
+}
+
+// keep returns the offset in the buffer from which bytes need to be kept.
+func (s *scanner) keep() int {
+	keep := s.offset
+	if s.marked {
+		keep = min(keep, s.mark-s.discarded)
+	}
+	if s.comments.found {
+		keep = min(keep, s.comments.start-s.discarded)
+	}
+	return keep
+}
+
+// Mark marks the start of the current token, so that it is kept in the buffer until Marked or Unmark is called.
+func (s *scanner) Mark() {
+	s.marked = true
+	s.mark = s.start
+}
+
+// Marked returns the input from the start of the marked token up to the offset and removes the mark.
+func (s *scanner) Marked() []byte {
+	s.marked = false
+	return s.buf[s.mark-s.discarded : s.offset]
+}
+
+// Unmark removes the mark.
+func (s *scanner) Unmark() {
+	s.marked = false
+}
+
+// fill discards the bytes before the offset and then reads more bytes from the reader into the window.
+// The window is grown if it is full.
+// If there is no reader, then the client needs to feed more input.
+func (s *scanner) fill() error {
+	if s.reader == nil {
+		return ErrNeedMoreInput
+	}
+	s.discard()
+	if len(s.buf) == cap(s.buf) {
+		s.window = make([]byte, len(s.buf), 2*cap(s.buf))
+		copy(s.window, s.buf)
+		s.buf = s.window
+	}
+	for range maxEmptyReads {
+		n, err := s.reader.Read(s.buf[len(s.buf):cap(s.buf)])
+		s.buf = s.buf[:len(s.buf)+n]
+		if err := s.checkInput(); err != nil {
+			return err
+		}
+		if err == io.EOF {
+			s.more = false
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return nil
+		}
+	}
+	return io.ErrNoProgress
 }
 
 func (s *scanner) Skip(offset int) error {
 	s.offset += offset
-	if s.offset > len(s.buf) {
+	if s.offset > len(s.scannable()) {
 		return io.ErrShortBuffer
 	}
 	return nil
@@ -77,10 +399,17 @@ func (s *scanner) Skip(offset int) error {
 
 func (s *scanner) ScanToEnd(k Kind) ([]byte, error) {
 	start := s.offset
-	end, err := NextEnd(k, s.buf, s.offset)
+	buf := s.scannable()
+	end, err := s.end(k, buf, s.offset)
 	if err != nil {
+		if err == io.ErrShortBuffer {
+			return nil, err
+		}
+		return nil, s.SyntaxError(err, k, nil)
+	}
+	if err := s.checkToken(k, buf[start:end], false); err != nil {
 		return nil, err
 	}
 	s.offset = end
-	return s.buf[start:s.offset], nil
+	return buf[start:s.offset], nil
 }
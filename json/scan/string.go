@@ -14,6 +14,11 @@
 
 package scan
 
+import (
+	"io"
+	"unicode/utf8"
+)
+
 // String returns the offset after the quoted string.
 // A string starts and ends with a double quote '"'.
 // The string BNF:
@@ -23,6 +28,43 @@ package scan
 // escape := '"' | '\' | '/' | 'b' | 'f' | 'n' | 'r' | 't' | 'u' hex hex hex hex
 // hex := digit | 'A' . 'F' | 'a' . 'f'
 func String(buf []byte) (int, error) {
+	n, err := stringEnd(buf)
+	if err != nil {
+		return 0, errScanString
+	}
+	return n, nil
+}
+
+// StrictString is the same as String, except that it also validates that the string is valid UTF-8
+// and that every escaped surrogate is part of a surrogate pair.
+// It returns ErrInvalidUTF8 or ErrLoneSurrogate if it is not.
+func StrictString(buf []byte) (int, error) {
+	n, err := strictStringEnd(buf)
+	if err == ErrInvalidUTF8 || err == ErrLoneSurrogate {
+		return 0, err
+	}
+	if err != nil {
+		return 0, errScanString
+	}
+	return n, nil
+}
+
+// stringEnd is the same as String, except that it returns io.ErrUnexpectedEOF,
+// if the end of the buffer was reached before the string was closed.
+func stringEnd(buf []byte) (int, error) {
+	return scanStringEnd(buf, &plaintable, false)
+}
+
+// strictStringEnd is the same as StrictString, except that it returns io.ErrUnexpectedEOF,
+// if the end of the buffer was reached before the string was closed.
+func strictStringEnd(buf []byte) (int, error) {
+	return scanStringEnd(buf, &stricttable, true)
+}
+
+// scanStringEnd scans to the end of the string.
+// Bytes that are not plain according to the table are checked.
+// If strict is true, then multi-byte UTF-8 characters and surrogate escapes are also checked.
+func scanStringEnd(buf []byte, table *[256]byte, strict bool) (int, error) {
 	if len(buf) == 0 || buf[0] != '"' {
 		return 0, errScanString
 	}
@@ -30,13 +72,25 @@ func String(buf []byte) (int, error) {
 	for i < len(buf) {
 		c := buf[i]
 		i++
-		isplain := plaintable[c]
+		isplain := table[c]
 		if isplain == 0 {
 			continue
 		}
+		if c >= utf8.RuneSelf {
+			// Only the strict table marks the start of multi-byte characters.
+			r, size := utf8.DecodeRune(buf[i-1:])
+			if r == utf8.RuneError && size == 1 {
+				if !utf8.FullRune(buf[i-1:]) {
+					return 0, io.ErrUnexpectedEOF
+				}
+				return 0, ErrInvalidUTF8
+			}
+			i += size - 1
+			continue
+		}
 		if c == '\\' {
 			if i >= len(buf) {
-				return 0, errScanString
+				return 0, io.ErrUnexpectedEOF
 			}
 			switch buf[i] {
 			case 'b', 'f', 'n', 'r', 't', '\\', '/', '"':
@@ -44,7 +98,7 @@ func String(buf []byte) (int, error) {
 			case 'u':
 				i += 4
 				if i >= len(buf) {
-					return 0, errScanString
+					return 0, io.ErrUnexpectedEOF
 				}
 				c4 := buf[i]
 				c3 := buf[i-1]
@@ -53,6 +107,13 @@ func String(buf []byte) (int, error) {
 				if !hextable[c1] || !hextable[c2] || !hextable[c3] || !hextable[c4] {
 					return 0, errScanString
 				}
+				if strict {
+					n, err := surrogate(hex4(c1, c2, c3, c4), buf[i+1:])
+					if err != nil {
+						return 0, err
+					}
+					i += n
+				}
 			default:
 				return 0, errScanString
 			}
@@ -63,17 +124,63 @@ func String(buf []byte) (int, error) {
 		}
 		return 0, errScanString
 	}
-	return 0, errScanString
+	return 0, io.ErrUnexpectedEOF
+}
+
+// surrogate checks that a high surrogate is followed by an escaped low surrogate and that a low surrogate is not alone.
+// It returns the number of bytes of the escaped low surrogate that follows a high surrogate.
+func surrogate(r rune, rest []byte) (int, error) {
+	if r < 0xD800 || r > 0xDFFF {
+		return 0, nil
+	}
+	if r >= 0xDC00 {
+		return 0, ErrLoneSurrogate
+	}
+	if len(rest) > 0 && rest[0] != '\\' || len(rest) > 1 && rest[1] != 'u' {
+		return 0, ErrLoneSurrogate
+	}
+	if len(rest) < 6 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if !hextable[rest[2]] || !hextable[rest[3]] || !hextable[rest[4]] || !hextable[rest[5]] {
+		return 0, errScanString
+	}
+	if low := hex4(rest[2], rest[3], rest[4], rest[5]); low < 0xDC00 || low > 0xDFFF {
+		return 0, ErrLoneSurrogate
+	}
+	return 6, nil
+}
+
+// hex4 returns the value of four hexadecimal digits.
+func hex4(c1, c2, c3, c4 byte) rune {
+	return hexval(c1)<<12 | hexval(c2)<<8 | hexval(c3)<<4 | hexval(c4)
+}
+
+func hexval(c byte) rune {
+	switch {
+	case c >= 'a':
+		return rune(c - 'a' + 10)
+	case c >= 'A':
+		return rune(c - 'A' + 10)
+	}
+	return rune(c - '0')
 }
 
 var plaintable = [256]byte{}
 
+// stricttable is the same as plaintable, but also marks the bytes that start or continue multi-byte UTF-8 characters.
+var stricttable = [256]byte{}
+
 func init() {
 	for i := range 0x20 {
 		plaintable[i] = 1
 	}
 	plaintable['"'] = 1
 	plaintable['\\'] = 1
+	stricttable = plaintable
+	for i := utf8.RuneSelf; i < 256; i++ {
+		stricttable[i] = 1
+	}
 }
 
 var hextable = [256]bool{
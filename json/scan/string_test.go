@@ -72,3 +72,54 @@ func TestRandString(t *testing.T) {
 		})
 	}
 }
+
+func TestStrictString(t *testing.T) {
+	valid := map[string]int{
+		`"a"`:              3,
+		"\"\u00e9\" ":      4,
+		"\"\U0001F600\"":   6,
+		"\"\uFFFD\"":       5,
+		`"\u00e9"`:         8,
+		`"\ud83d\ude00" `:  14,
+		`"a\ud83d\ude00b"`: 16,
+		`"\ud7ff"`:         8,
+		`"\\ud800"`:        9,
+		`"\uD83D\uDE00\""`: 16,
+	}
+	invalid := map[string]error{
+		"\"\xff\"":         ErrInvalidUTF8,
+		"\"\xc3\"":         ErrInvalidUTF8,
+		"\"\xed\xa0\x80\"": ErrInvalidUTF8,
+		`"\ud800"`:         ErrLoneSurrogate,
+		`"\udc00"`:         ErrLoneSurrogate,
+		`"\ud800\u0041"`:   ErrLoneSurrogate,
+		`"\ud800a"`:        ErrLoneSurrogate,
+		`"\ud800\n"`:       ErrLoneSurrogate,
+		`"\ud800\ud800"`:   ErrLoneSurrogate,
+		`"\ud800\u00`:      errScanString,
+		"\"\xc3":           errScanString,
+		`"\ud800\uzzzz"`:   errScanString,
+	}
+	for input, want := range valid {
+		t.Run("Valid("+input+")", func(t *testing.T) {
+			got, err := StrictString([]byte(input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != want {
+				t.Fatalf("offset want %d, but got %d", want, got)
+			}
+		})
+	}
+	for input, want := range invalid {
+		t.Run("Invalid("+input+")", func(t *testing.T) {
+			if _, err := StrictString([]byte(input)); err != want {
+				t.Fatalf("want %v, but got %v", want, err)
+			}
+			// String is not strict.
+			if _, err := String([]byte(input)); want != errScanString && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
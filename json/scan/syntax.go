@@ -0,0 +1,91 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// SyntaxError is returned when the input is not valid JSON.
+// It wraps the underlying error, so that errors.Is still matches it.
+type SyntaxError struct {
+	// Err is the underlying error, for example "unable to scan string".
+	Err error
+	// Position is the position of the token where the error occurred.
+	Position
+	// Expected are the kinds of tokens that were expected, if they are known.
+	Expected []Kind
+	// Found is the kind of the token that was found.
+	Found Kind
+	// Excerpt is a short excerpt of the input around the token.
+	Excerpt string
+}
+
+func (e *SyntaxError) Error() string {
+	msg := e.Position.String() + ": " + e.Err.Error()
+	if e.Found != UnknownKind {
+		msg += ", found " + e.Found.String()
+	}
+	if len(e.Excerpt) > 0 {
+		msg += " near " + strconv.Quote(e.Excerpt)
+	}
+	return msg
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// excerptBefore and excerptAfter are the maximum number of bytes of the excerpt before and after the start of a token.
+const (
+	excerptBefore = 8
+	excerptAfter  = 16
+)
+
+// SyntaxError returns a SyntaxError at the position of the current token.
+// It is only called once an error has occurred, so that the happy path does not allocate.
+func (s *scanner) SyntaxError(err error, found Kind, expected []Kind) error {
+	return &SyntaxError{
+		Err:      err,
+		Position: s.Position(),
+		Expected: expected,
+		Found:    found,
+		Excerpt:  s.excerpt(),
+	}
+}
+
+// excerpt returns a short excerpt of the input around the current token,
+// without cutting UTF-8 encoded characters in half or including previous records.
+func (s *scanner) excerpt() string {
+	buf := s.scannable()
+	start := s.start - s.discarded
+	if start < 0 || start > len(buf) {
+		// The current token has already been discarded.
+		return ""
+	}
+	from := max(start-excerptBefore, 0)
+	if s.record {
+		from = max(from, s.recordStart-s.discarded)
+	}
+	for from < start && !utf8.RuneStart(buf[from]) {
+		from++
+	}
+	to := min(start+excerptAfter, len(buf))
+	for to > start && to < len(buf) && !utf8.RuneStart(buf[to]) {
+		to--
+	}
+	return string(buf[from:to])
+}
@@ -0,0 +1,64 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package scan
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSyntaxErrorString(t *testing.T) {
+	s := NewScanner([]byte("[\n  \"abc\\x\"]"))
+	expect(t, next(s), ArrayOpenKind)
+	_, _, err := Next(s)
+	if !errors.Is(err, errScanString) {
+		t.Fatalf("expected scan string error, but got %v", err)
+	}
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected syntax error, but got %v", err)
+	}
+	if want := (Position{Offset: 4, Line: 2, Column: 3}); syntaxErr.Position != want {
+		t.Fatalf("want position %v, but got %v", want, syntaxErr.Position)
+	}
+	if syntaxErr.Found != StringKind {
+		t.Fatalf("want found %v, but got %v", StringKind, syntaxErr.Found)
+	}
+	if want := "[\n  \"abc\\x\"]"; syntaxErr.Excerpt != want {
+		t.Fatalf("want excerpt %q, but got %q", want, syntaxErr.Excerpt)
+	}
+	if want := `2:3: unable to scan string, found string near "[\n  \"abc\\x\"]"`; err.Error() != want {
+		t.Fatalf("want error %s, but got %s", want, err.Error())
+	}
+}
+
+func TestSyntaxErrorExcerptUTF8(t *testing.T) {
+	s := NewScanner([]byte(`["ééééééé", tru]`))
+	expect(t, next(s), ArrayOpenKind)
+	expect(t, next(s), StringKind)
+	expect(t, next(s), CommaKind)
+	_, _, err := Next(s)
+	if !errors.Is(err, errExpectedTrue) {
+		t.Fatalf("expected true error, but got %v", err)
+	}
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("expected syntax error, but got %v", err)
+	}
+	// The excerpt starts 8 bytes before the token, but does not cut a character in half.
+	if want := `éé", tru]`; syntaxErr.Excerpt != want {
+		t.Fatalf("want excerpt %q, but got %q", want, syntaxErr.Excerpt)
+	}
+}
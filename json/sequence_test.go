@@ -0,0 +1,82 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/rand"
+)
+
+// NextDocument feeds the parser one byte at a time, whenever it needs more input to find the next document.
+func (p *fedParser) NextDocument() error {
+	for {
+		err := p.Parser.NextDocument()
+		if err != parse.ErrNeedMoreInput {
+			return err
+		}
+		p.feed()
+	}
+}
+
+func expectSameDocuments(t *testing.T, values [][]byte, got Parser) {
+	t.Helper()
+	want := NewParser()
+	for _, value := range values {
+		if err := got.NextDocument(); err != nil {
+			t.Fatalf("expected next document, but got %v", err)
+		}
+		want.Init(value)
+		expectSameWalk(t, want, got)
+	}
+	if err := got.NextDocument(); err != io.EOF {
+		t.Fatalf("expected EOF, but got %v", err)
+	}
+}
+
+func TestSequenceSameAsInit(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	var input []byte
+	for _, value := range values {
+		input = append(input, 0x1E)
+		input = append(input, value...)
+		input = append(input, '\n')
+	}
+	got := NewSequenceParser()
+	got.Init(input)
+	expectSameDocuments(t, values, got)
+}
+
+func TestConcatenatedSameAsInit(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	got := NewConcatenatedParser()
+	// Values such as numbers need to be separated by whitespace.
+	got.Init(bytes.Join(values, []byte(" ")))
+	expectSameDocuments(t, values, got)
+}
+
+func TestConcatenatedFeedSameAsInit(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	got := NewConcatenatedParser()
+	got.Init(nil)
+	got.Feed(nil)
+	expectSameDocuments(t, values, &fedParser{Parser: got, input: bytes.Join(values, []byte(" "))})
+}
@@ -0,0 +1,50 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package tag_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	jsonparse "github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/tag"
+	"github.com/katydid/parser-go/parse/debug"
+)
+
+func TestMaxDepth(t *testing.T) {
+	options := map[string][]tag.Option{
+		"None":        {},
+		"WithIndexes": {tag.WithIndexes()},
+		"WithTags":    {tag.WithTags()},
+		"WithBoth":    {tag.WithTags(), tag.WithIndexes()},
+	}
+	for name, opts := range options {
+		t.Run(name, func(t *testing.T) {
+			opts = append(opts, tag.WithMaxDepth(4))
+			// The underlying parser does not limit the depth, so only the tagger can.
+			ok := []byte(strings.Repeat(`{"a":[`, 2) + "1" + strings.Repeat("]}", 2))
+			p := tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer(ok)), opts...)
+			if err := debug.Walk(p); err != nil {
+				t.Fatal(err)
+			}
+			deep := []byte(`{"a":[{"a":[[1]]}]}`)
+			p = tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer(deep)), opts...)
+			if err := debug.Walk(p); !errors.Is(err, tag.ErrMaxDepthExceeded) {
+				t.Fatalf("expected %v, but got %v", tag.ErrMaxDepthExceeded, err)
+			}
+		})
+	}
+}
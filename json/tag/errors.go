@@ -14,10 +14,23 @@
 
 package tag
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/katydid/parser-go-json/json/scan"
+	"github.com/katydid/parser-go-json/json/token"
+)
 
 var errUnexpectedClose = errors.New("unexpected `}` or `]`")
 
 var errExpectedTag = errors.New("expected tag")
 
 var errUnknownJSONSchemaType = errors.New("unknown json schema type")
+
+// ErrMaxDepthExceeded is returned by Next when arrays and objects are nested deeper than the maximum set using WithMaxDepth.
+// It is the same error as parse.ErrMaxDepthExceeded, from the json/parse package.
+var ErrMaxDepthExceeded = scan.ErrMaxDepthExceeded
+
+// ErrNotUint is returned by Uint when the current value is not an integer between 0 and math.MaxUint64.
+// It is the same error as parse.ErrNotUint, from the json/parse package.
+var ErrNotUint = token.ErrNotUint
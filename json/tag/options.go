@@ -43,3 +43,21 @@ func WithAllocator(alloc func(int) []byte) func(*tagger) {
 		o.alloc = alloc
 	}
 }
+
+// WithPath keeps track of the field names and array indexes of the path to the current token,
+// which can be retrieved using Path or Pointer.
+func WithPath() func(*tagger) {
+	return func(t *tagger) {
+		t.trackPath = true
+	}
+}
+
+// WithMaxDepth limits the nesting depth of arrays and objects to the given maximum,
+// not counting the extra levels added by tags.
+// Next returns ErrMaxDepthExceeded when the input is nested deeper.
+// A maximum of zero, the default, means that the depth is not limited.
+func WithMaxDepth(max int) func(*tagger) {
+	return func(t *tagger) {
+		t.maxDepth = max
+	}
+}
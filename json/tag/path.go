@@ -0,0 +1,199 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package tag
+
+import (
+	"strconv"
+
+	"github.com/katydid/parser-go-json/json/jsonschema"
+	"github.com/katydid/parser-go/parse"
+)
+
+// Segment is a field name or an array index in the path to the current token.
+type Segment struct {
+	// Key is the field name, if this segment is not an array index.
+	Key []byte
+	// Index is the array index, if IsIndex is true.
+	Index   int64
+	IsIndex bool
+}
+
+// level is an object or array that is being parsed.
+type level struct {
+	array bool
+	// index of the current array element, which is -1 before the first element.
+	index int64
+	// keyStart and keyEnd are the offsets of the current field name in pathTracker.keys.
+	keyStart int
+	keyEnd   int
+	// set is false until the first field or element has been parsed.
+	set bool
+}
+
+// pathTracker wraps the parser and keeps track of the field names and array indexes,
+// based on the hints that the underlying parser returns, so that the tags and indexes that the tagger adds are not part of the path.
+type pathTracker struct {
+	JSONSchemaAbleParser
+	levels []level
+	// keys is a stack of the current field names of all the levels.
+	keys []byte
+	// field is true if the last hint was a FieldHint, which means that Skip skips the value of the field.
+	field bool
+	// entered is true if the last hint was an EnterHint, which means that RawValue skips the object or array.
+	entered bool
+
+	// segments and pointer are reused by Path and Pointer.
+	segments []Segment
+	pointer  []byte
+}
+
+func newPathTracker(p JSONSchemaAbleParser) *pathTracker {
+	return &pathTracker{
+		JSONSchemaAbleParser: p,
+		levels:               make([]level, 0, 10),
+	}
+}
+
+func (t *pathTracker) Reset() {
+	t.levels = t.levels[:0]
+	t.keys = t.keys[:0]
+	t.field = false
+	t.entered = false
+	t.JSONSchemaAbleParser.Reset()
+}
+
+func (t *pathTracker) Next() (parse.Hint, error) {
+	h, err := t.JSONSchemaAbleParser.Next()
+	if err != nil {
+		return h, err
+	}
+	t.field = false
+	t.entered = false
+	switch h {
+	case parse.FieldHint:
+		_, key, err := t.JSONSchemaAbleParser.Token()
+		if err != nil {
+			return parse.UnknownHint, err
+		}
+		top := &t.levels[len(t.levels)-1]
+		t.keys = append(t.keys[:top.keyStart], key...)
+		top.keyEnd = len(t.keys)
+		top.set = true
+		t.field = true
+	case parse.ValueHint:
+		t.element()
+	case parse.EnterHint:
+		t.element()
+		t.levels = append(t.levels, level{
+			array:    t.JSONSchemaType() == jsonschema.JSONSchemaTypeArray,
+			index:    -1,
+			keyStart: len(t.keys),
+			keyEnd:   len(t.keys),
+		})
+		t.entered = true
+	case parse.LeaveHint:
+		t.up()
+	}
+	return h, nil
+}
+
+// element moves to the next index, if the current level is an array.
+func (t *pathTracker) element() {
+	if len(t.levels) == 0 {
+		return
+	}
+	top := &t.levels[len(t.levels)-1]
+	if top.array {
+		top.index++
+		top.set = true
+	}
+}
+
+func (t *pathTracker) up() {
+	if len(t.levels) == 0 {
+		return
+	}
+	top := t.levels[len(t.levels)-1]
+	t.keys = t.keys[:top.keyStart]
+	t.levels = t.levels[:len(t.levels)-1]
+}
+
+func (t *pathTracker) Skip() error {
+	if err := t.JSONSchemaAbleParser.Skip(); err != nil {
+		return err
+	}
+	if t.field {
+		// Only the value of the field was skipped.
+		t.field = false
+		return nil
+	}
+	// The rest of the object or array was skipped.
+	t.entered = false
+	t.up()
+	return nil
+}
+
+func (t *pathTracker) RawValue() ([]byte, error) {
+	raw, err := t.JSONSchemaAbleParser.RawValue()
+	if err != nil {
+		return nil, err
+	}
+	if t.entered {
+		// The object or array that was just entered, was skipped.
+		t.up()
+	}
+	t.field = false
+	t.entered = false
+	return raw, nil
+}
+
+// Path returns the field names and array indexes of the path to the current token.
+func (t *pathTracker) Path() []Segment {
+	t.segments = t.segments[:0]
+	for _, l := range t.levels {
+		if !l.set {
+			break
+		}
+		if l.array {
+			t.segments = append(t.segments, Segment{Index: l.index, IsIndex: true})
+		} else {
+			t.segments = append(t.segments, Segment{Key: t.keys[l.keyStart:l.keyEnd]})
+		}
+	}
+	return t.segments
+}
+
+// Pointer returns the path to the current token as a JSON Pointer (RFC 6901).
+func (t *pathTracker) Pointer() []byte {
+	t.pointer = t.pointer[:0]
+	for _, s := range t.Path() {
+		t.pointer = append(t.pointer, '/')
+		if s.IsIndex {
+			t.pointer = strconv.AppendInt(t.pointer, s.Index, 10)
+			continue
+		}
+		for _, c := range s.Key {
+			switch c {
+			case '~':
+				t.pointer = append(t.pointer, '~', '0')
+			case '/':
+				t.pointer = append(t.pointer, '~', '1')
+			default:
+				t.pointer = append(t.pointer, c)
+			}
+		}
+	}
+	return t.pointer
+}
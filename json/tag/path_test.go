@@ -0,0 +1,208 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package tag_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/internal/testrun"
+	jsonparse "github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/pointer"
+	"github.com/katydid/parser-go-json/json/rand"
+	"github.com/katydid/parser-go-json/json/tag"
+	"github.com/katydid/parser-go/parse"
+	"github.com/katydid/parser-go/parse/debug"
+	"github.com/katydid/parser-go/pool"
+)
+
+func expectPointer(t *testing.T, p tag.Parser, want parse.Hint, pointer string) {
+	t.Helper()
+	hint, err := p.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hint != want {
+		t.Fatalf("want hint %v, but got %v", want, hint)
+	}
+	if got := string(p.Pointer()); got != pointer {
+		t.Fatalf("want pointer %q, but got %q", pointer, got)
+	}
+}
+
+const pathInput = `{"a":[1,{"b/c":true}],"d~":{"e":null}}`
+
+func TestPathWithIndexes(t *testing.T) {
+	p := tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer([]byte(pathInput))), tag.WithIndexes(), tag.WithPath())
+	expectPointer(t, p, parse.EnterHint, "")
+	expectPointer(t, p, parse.FieldHint, "/a")
+	expectPointer(t, p, parse.EnterHint, "/a")
+	expectPointer(t, p, parse.FieldHint, "/a/0")
+	expectPointer(t, p, parse.ValueHint, "/a/0")
+	expectPointer(t, p, parse.FieldHint, "/a/1")
+	expectPointer(t, p, parse.EnterHint, "/a/1")
+	expectPointer(t, p, parse.FieldHint, "/a/1/b~1c")
+	expectPointer(t, p, parse.ValueHint, "/a/1/b~1c")
+	expectPointer(t, p, parse.LeaveHint, "/a/1")
+	expectPointer(t, p, parse.LeaveHint, "/a")
+	expectPointer(t, p, parse.FieldHint, "/d~0")
+	expectPointer(t, p, parse.EnterHint, "/d~0")
+	expectPointer(t, p, parse.FieldHint, "/d~0/e")
+	path := p.Path()
+	if len(path) != 2 || string(path[0].Key) != "d~" || path[0].IsIndex || string(path[1].Key) != "e" {
+		t.Fatalf("unexpected path %v", path)
+	}
+}
+
+func TestPathWithTags(t *testing.T) {
+	p := tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer([]byte(`{"a":[1]}`))), tag.WithTags(), tag.WithIndexes(), tag.WithPath())
+	expectPointer(t, p, parse.EnterHint, "")
+	// The "object" tag is not part of the path.
+	expectPointer(t, p, parse.FieldHint, "")
+	expectPointer(t, p, parse.EnterHint, "")
+	expectPointer(t, p, parse.FieldHint, "/a")
+	expectPointer(t, p, parse.EnterHint, "/a")
+	// The "array" tag is not part of the path.
+	expectPointer(t, p, parse.FieldHint, "/a")
+	expectPointer(t, p, parse.EnterHint, "/a")
+	expectPointer(t, p, parse.FieldHint, "/a/0")
+	expectPointer(t, p, parse.ValueHint, "/a/0")
+	path := p.Path()
+	if len(path) != 2 || !path[1].IsIndex || path[1].Index != 0 {
+		t.Fatalf("unexpected path %v", path)
+	}
+}
+
+func TestPathSkip(t *testing.T) {
+	p := tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer([]byte(pathInput))), tag.WithIndexes(), tag.WithPath())
+	expectPointer(t, p, parse.EnterHint, "")
+	expectPointer(t, p, parse.FieldHint, "/a")
+	expectPointer(t, p, parse.EnterHint, "/a")
+	expectPointer(t, p, parse.FieldHint, "/a/0")
+	// Skip the value of the index.
+	if err := p.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	expectPointer(t, p, parse.FieldHint, "/a/1")
+	// Skip the object.
+	if err := p.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	expectPointer(t, p, parse.LeaveHint, "/a")
+	expectPointer(t, p, parse.FieldHint, "/d~0")
+	// Skip the value of the field.
+	if err := p.Skip(); err != nil {
+		t.Fatal(err)
+	}
+	expectPointer(t, p, parse.LeaveHint, "")
+}
+
+func TestPathWithoutWithPath(t *testing.T) {
+	p := tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer([]byte(pathInput))))
+	if _, err := p.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if p.Pointer() != nil || p.Path() != nil {
+		t.Fatal("expected no path")
+	}
+}
+
+func TestPathNotASingleAllocAfterWarmUp(t *testing.T) {
+	pool := pool.New()
+	p := jsonparse.NewParser(jsonparse.WithAllocator(pool.Alloc))
+	tagger := tag.NewTagger(p, tag.WithAllocator(pool.Alloc), tag.WithIndexes(), tag.WithPath())
+	walker := &pointerWalker{tagger}
+	testrun.NotASingleAllocAfterWarmUp(t, pool, func(bs []byte) {
+		tagger.Reset()
+		p.Init(bs)
+		if err := debug.Walk(walker); err != nil {
+			t.Fatalf("expected EOF, but got %v", err)
+		}
+	})
+}
+
+// pointerWalker asks for the pointer after every call to Next.
+type pointerWalker struct {
+	tag.Parser
+}
+
+func (w *pointerWalker) Next() (parse.Hint, error) {
+	h, err := w.Parser.Next()
+	_ = w.Pointer()
+	return h, err
+}
+
+// hasDuplicateKeys reports whether an object in the value contains the same key more than once,
+// by checking whether two fields have the same pointer.
+func hasDuplicateKeys(value []byte) bool {
+	p := tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer(value)), tag.WithIndexes(), tag.WithPath())
+	seen := make(map[string]bool)
+	for {
+		hint, err := p.Next()
+		if err != nil {
+			return false
+		}
+		if hint != parse.FieldHint {
+			continue
+		}
+		ptr := string(p.Pointer())
+		if seen[ptr] {
+			return true
+		}
+		seen[ptr] = true
+	}
+}
+
+// TestPathRandomValuesSeek checks that seeking to the pointer of each value, finds the same value.
+func TestPathRandomValuesSeek(t *testing.T) {
+	r := rand.NewRand()
+	values := rand.Values(r, 100)
+	for _, value := range values {
+		t.Run(testrun.Name(value), func(t *testing.T) {
+			// Seek finds the first of duplicate keys, so the path of a later duplicate key does not lead back to its value.
+			if hasDuplicateKeys(value) {
+				t.Skip("duplicate keys")
+			}
+			p := tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer(value)), tag.WithIndexes(), tag.WithPath())
+			for {
+				hint, err := p.Next()
+				if err != nil {
+					return
+				}
+				if hint != parse.ValueHint {
+					continue
+				}
+				_, token, err := p.Token()
+				if err != nil {
+					t.Fatal(err)
+				}
+				// The token is only valid until the next call to the parser, so it is copied.
+				want := append([]byte(nil), token...)
+				ptr := string(p.Pointer())
+				seeker := tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer(value)), tag.WithIndexes())
+				if _, err := pointer.Seek(seeker, ptr); err != nil {
+					t.Fatalf("%s: %v", ptr, err)
+				}
+				_, got, err := seeker.Token()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(want, got) {
+					t.Fatalf("%s: want %s, but got %s", ptr, want, got)
+				}
+			}
+		})
+	}
+}
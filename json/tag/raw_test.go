@@ -0,0 +1,38 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package tag_test
+
+import (
+	"testing"
+
+	jsonparse "github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/tag"
+	"github.com/katydid/parser-go/parse"
+)
+
+func TestPathRawValue(t *testing.T) {
+	p := tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer([]byte(pathInput))), tag.WithIndexes(), tag.WithPath())
+	expectPointer(t, p, parse.EnterHint, "")
+	expectPointer(t, p, parse.FieldHint, "/a")
+	expectPointer(t, p, parse.EnterHint, "/a")
+	if _, err := p.RawValue(); err != nil {
+		t.Fatal(err)
+	}
+	expectPointer(t, p, parse.FieldHint, "/d~0")
+	if _, err := p.RawValue(); err != nil {
+		t.Fatal(err)
+	}
+	expectPointer(t, p, parse.LeaveHint, "")
+}
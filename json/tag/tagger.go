@@ -19,6 +19,7 @@ import (
 	"io"
 
 	"github.com/katydid/parser-go-json/json/jsonschema"
+	"github.com/katydid/parser-go-json/json/scan"
 	"github.com/katydid/parser-go/cast"
 	"github.com/katydid/parser-go/parse"
 )
@@ -26,19 +27,49 @@ import (
 type Parser interface {
 	parse.Parser
 	Reset()
+	// Offset returns the offset in bytes of the current token of the underlying parser from the start of the input.
+	Offset() int
+	// Position returns the offset, line and column of the current token of the underlying parser.
+	Position() scan.Position
+	// RawValue skips over the current value of the underlying parser and returns the exact bytes of the input that it spans.
+	RawValue() ([]byte, error)
+	// Comment returns the comments before the current token of the underlying parser, if it allows comments.
+	Comment() []byte
+	// Uint returns the current token as an unsigned integer, if it is an integer between 0 and math.MaxUint64.
+	Uint() (uint64, error)
+	// JSONSchemaType distinguishes between objects and arrays, after Next returned an EnterHint.
+	// The objects that wrap tagged values are objects.
+	JSONSchemaType() jsonschema.JSONSchemaType
+	// Path returns the field names and array indexes of the path to the current token, if the tagger was created WithPath.
+	// The returned slice is reused by the next call to Path.
+	Path() []Segment
+	// Pointer returns the path to the current token as a JSON Pointer, if the tagger was created WithPath.
+	// The returned slice is reused by the next call to Pointer.
+	Pointer() []byte
 }
 
 type JSONSchemaAbleParser interface {
 	parse.Parser
 	jsonschema.JSONSchemaAble
 	Reset()
+	RawValue() ([]byte, error)
+	Offset() int
+	Position() scan.Position
+	Comment() []byte
+	Uint() (uint64, error)
 }
 
 type tagger struct {
-	p     JSONSchemaAbleParser
-	tag   bool
-	index bool
-	alloc func(size int) []byte
+	p         JSONSchemaAbleParser
+	tag       bool
+	index     bool
+	alloc     func(size int) []byte
+	trackPath bool
+	maxDepth  int
+	// maxStack is the maximum length of the stack, calculated from maxDepth.
+	maxStack int
+	// path is only set if the tagger was created WithPath.
+	path *pathTracker
 	// state
 	state state
 	stack []state
@@ -66,6 +97,16 @@ func NewTagger(p JSONSchemaAbleParser, opts ...Option) Parser {
 	for _, opt := range opts {
 		opt(t)
 	}
+	if t.tag {
+		// Tags push two states onto the stack for each object or array: one for the tag and one for its value.
+		t.maxStack = 2 * t.maxDepth
+	} else {
+		t.maxStack = t.maxDepth
+	}
+	if t.trackPath {
+		t.path = newPathTracker(p)
+		t.p = t.path
+	}
 	return t
 }
 
@@ -79,16 +120,77 @@ func (t *tagger) Reset() {
 	t.p.Reset()
 }
 
+// Offset returns the offset of the current token of the underlying parser,
+// which for tags and indexes is the offset of the object, array or element that they tag.
+func (t *tagger) Offset() int {
+	return t.p.Offset()
+}
+
+// Position returns the position of the current token of the underlying parser,
+// which for tags and indexes is the position of the object, array or element that they tag.
+func (t *tagger) Position() scan.Position {
+	return t.p.Position()
+}
+
+// Comment returns the comments before the current token of the underlying parser,
+// which for tags and indexes are the comments before the object, array or element that they tag.
+func (t *tagger) Comment() []byte {
+	return t.p.Comment()
+}
+
+// JSONSchemaType returns the type of the underlying object or array that was entered,
+// or JSONSchemaTypeObject for the objects that wrap tagged objects and arrays, see WithTags.
+func (t *tagger) JSONSchemaType() jsonschema.JSONSchemaType {
+	switch t.state.kind {
+	case objectTagOpenState, arrayTagOpenState:
+		return jsonschema.JSONSchemaTypeObject
+	}
+	return t.p.JSONSchemaType()
+}
+
+// Uint returns the current token of the underlying parser as an unsigned integer,
+// or the array index, if the current token is an index, see WithIndexes.
+func (t *tagger) Uint() (uint64, error) {
+	switch t.state.kind {
+	case objectTagKeyOpenState, arrayTagKeyOpenState:
+		return 0, ErrNotUint
+	case arrayTagElemState:
+		return uint64(t.state.arrayIndex), nil
+	}
+	return t.p.Uint()
+}
+
+// Path returns the field names and array indexes of the path to the current token, if the tagger was created WithPath.
+// Tags and indexes that were added by the tagger are not part of the path.
+func (t *tagger) Path() []Segment {
+	if t.path == nil {
+		return nil
+	}
+	return t.path.Path()
+}
+
+// Pointer returns the path to the current token as a JSON Pointer, if the tagger was created WithPath.
+func (t *tagger) Pointer() []byte {
+	if t.path == nil {
+		return nil
+	}
+	return t.path.Pointer()
+}
+
 func (t *tagger) nextStart(h parse.Hint) (parse.Hint, error) {
 	if t.tag {
 		switch h {
 		case parse.EnterHint:
 			switch t.p.JSONSchemaType() {
 			case jsonschema.JSONSchemaTypeArray:
-				t.down(arrayTagOpenState)
+				if err := t.down(arrayTagOpenState); err != nil {
+					return parse.UnknownHint, err
+				}
 				return parse.EnterHint, nil
 			case jsonschema.JSONSchemaTypeObject:
-				t.down(objectTagOpenState)
+				if err := t.down(objectTagOpenState); err != nil {
+					return parse.UnknownHint, err
+				}
 				return parse.EnterHint, nil
 			}
 			return parse.UnknownHint, errUnknownJSONSchemaType
@@ -103,10 +205,14 @@ func (t *tagger) nextStart(h parse.Hint) (parse.Hint, error) {
 		case parse.EnterHint:
 			switch t.p.JSONSchemaType() {
 			case jsonschema.JSONSchemaTypeArray:
-				t.down(arrayTagIndexState)
+				if err := t.down(arrayTagIndexState); err != nil {
+					return parse.UnknownHint, err
+				}
 				return parse.EnterHint, nil
 			case jsonschema.JSONSchemaTypeObject:
-				t.down(startState)
+				if err := t.down(startState); err != nil {
+					return parse.UnknownHint, err
+				}
 				return parse.EnterHint, nil
 			}
 			return parse.UnknownHint, errUnknownJSONSchemaType
@@ -121,10 +227,14 @@ func (t *tagger) nextStart(h parse.Hint) (parse.Hint, error) {
 		case parse.EnterHint:
 			switch t.p.JSONSchemaType() {
 			case jsonschema.JSONSchemaTypeArray:
-				t.down(startState)
+				if err := t.down(startState); err != nil {
+					return parse.UnknownHint, err
+				}
 				return parse.EnterHint, nil
 			case jsonschema.JSONSchemaTypeObject:
-				t.down(startState)
+				if err := t.down(startState); err != nil {
+					return parse.UnknownHint, err
+				}
 				return parse.EnterHint, nil
 			}
 			return parse.UnknownHint, errUnknownJSONSchemaType
@@ -153,7 +263,9 @@ func (t *tagger) Next() (parse.Hint, error) {
 		return parse.FieldHint, nil
 	case objectTagKeyOpenState:
 		t.state.kind = objectTagKeyCloseState
-		t.down(startState)
+		if err := t.down(startState); err != nil {
+			return parse.UnknownHint, err
+		}
 		return parse.EnterHint, nil
 	case objectTagKeyCloseState:
 		if err := t.up(); err != nil {
@@ -171,9 +283,13 @@ func (t *tagger) Next() (parse.Hint, error) {
 	case arrayTagKeyOpenState:
 		t.state.kind = arrayTagKeyCloseState
 		if t.index {
-			t.down(arrayTagIndexState)
+			if err := t.down(arrayTagIndexState); err != nil {
+				return parse.UnknownHint, err
+			}
 		} else {
-			t.down(startState)
+			if err := t.down(startState); err != nil {
+				return parse.UnknownHint, err
+			}
 		}
 		return parse.EnterHint, nil
 	case arrayTagKeyCloseState:
@@ -261,6 +377,62 @@ func (t *tagger) Skip() error {
 	panic(fmt.Sprintf("unreachable: unknown state = %v", t.state))
 }
 
+// RawValue skips over the current value and returns the exact bytes of the input that it spans.
+// For tags the current value is the object or array that is tagged and for indexes it is the array element.
+func (t *tagger) RawValue() ([]byte, error) {
+	switch t.state.kind {
+	case objectTagKeyOpenState:
+		raw, err := t.p.RawValue()
+		if err != nil {
+			return nil, err
+		}
+		t.state.kind = objectTagKeyCloseState
+		return raw, nil
+	case arrayTagKeyOpenState:
+		raw, err := t.p.RawValue()
+		if err != nil {
+			return nil, err
+		}
+		t.state.kind = arrayTagKeyCloseState
+		return raw, nil
+	case arrayTagElemState:
+		raw, err := t.p.RawValue()
+		if err != nil {
+			return nil, err
+		}
+		t.state.kind = arrayTagIndexState
+		return raw, nil
+	case objectTagOpenState, arrayTagOpenState:
+		raw, err := t.p.RawValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := t.up(); err != nil {
+			return nil, err
+		}
+		return raw, nil
+	case startState, arrayTagIndexState:
+		raw, err := t.p.RawValue()
+		if err != nil {
+			return nil, err
+		}
+		switch t.state.hint {
+		case parse.EnterHint:
+			if len(t.stack) > 0 {
+				// The object or array that was just entered, was skipped.
+				if err := t.up(); err != nil {
+					return nil, err
+				}
+			}
+		case parse.FieldHint:
+			// The value of the field was skipped.
+			t.state.hint = parse.UnknownHint
+		}
+		return raw, nil
+	}
+	return t.p.RawValue()
+}
+
 func (t *tagger) Token() (parse.Kind, []byte, error) {
 	switch t.state.kind {
 	case objectTagKeyOpenState:
@@ -273,12 +445,16 @@ func (t *tagger) Token() (parse.Kind, []byte, error) {
 	return t.p.Token()
 }
 
-func (t *tagger) down(stateKind stateKind) {
+func (t *tagger) down(stateKind stateKind) error {
+	if t.maxStack > 0 && len(t.stack) >= t.maxStack {
+		return ErrMaxDepthExceeded
+	}
 	// Append the current state to the stack.
 	t.stack = append(t.stack, t.state)
 	// Create a new state.
 	t.state.kind = stateKind
 	t.state.arrayIndex = -1
+	return nil
 }
 
 func (t *tagger) up() error {
@@ -0,0 +1,148 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package token
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+
+	"github.com/katydid/parser-go/cast"
+	"github.com/katydid/parser-go/parse"
+)
+
+// BigInt sets z to the value of the number that was returned by Token and returns z.
+// The value of z is reused, so that decoding into the same z does not allocate after warm-up.
+// Decimals, including integers larger than math.MaxUint64, are decoded without losing precision.
+// It returns ErrNotInt if the number is not an integer, for example 1.5, and ErrNotNumber if the token is not a number.
+func BigInt(z *big.Int, kind parse.Kind, value []byte) (*big.Int, error) {
+	switch kind {
+	case parse.Int64Kind:
+		return z.SetInt64(cast.ToInt64(value)), nil
+	case parse.Float64Kind:
+		f := cast.ToFloat64(value)
+		if math.IsInf(f, 0) || math.IsNaN(f) || f != math.Trunc(f) {
+			return nil, ErrNotInt
+		}
+		var x big.Float
+		x.SetFloat64(f)
+		x.Int(z)
+		return z, nil
+	case parse.DecimalKind:
+		if setInteger(z, value) {
+			return z, nil
+		}
+		// The number has a fraction or an exponent, but could still be an integer, for example 1.0 or 1e3.
+		var r big.Rat
+		if _, ok := r.SetString(cast.ToString(value)); !ok || !r.IsInt() {
+			return nil, ErrNotInt
+		}
+		return z.Set(r.Num()), nil
+	}
+	return nil, ErrNotNumber
+}
+
+// chunkDigits is the number of decimal digits that always fit in a big.Word: 19 for 64 bit words and 9 for 32 bit words.
+const chunkDigits = 9 + 10*(bits.UintSize/64)
+
+// setInteger sets z to the integer in decimal text and returns false if the text contains anything other than an optional sign and digits.
+// Unlike big.Int.SetString, it does not allocate a reader,
+// since it multiplies the words of z in place by chunks of digits.
+func setInteger(z *big.Int, text []byte) bool {
+	neg := false
+	if len(text) > 0 && (text[0] == '-' || text[0] == '+') {
+		neg = text[0] == '-'
+		text = text[1:]
+	}
+	if len(text) == 0 {
+		return false
+	}
+	words := z.Bits()[:0]
+	for len(text) > 0 {
+		n := min(len(text), chunkDigits)
+		var chunk, pow uint = 0, 1
+		for _, c := range text[:n] {
+			if c < '0' || c > '9' {
+				return false
+			}
+			chunk = chunk*10 + uint(c-'0')
+			pow *= 10
+		}
+		words = mulAddWord(words, pow, chunk)
+		text = text[n:]
+	}
+	z.SetBits(words)
+	if neg {
+		z.Neg(z)
+	}
+	return true
+}
+
+// mulAddWord sets the little-endian words to words*m+a.
+func mulAddWord(words []big.Word, m, a uint) []big.Word {
+	carry := a
+	for i, w := range words {
+		hi, lo := bits.Mul(uint(w), m)
+		lo, c := bits.Add(lo, carry, 0)
+		words[i] = big.Word(lo)
+		carry = hi + c
+	}
+	if carry != 0 {
+		words = append(words, big.Word(carry))
+	}
+	return words
+}
+
+// BigFloat sets z to the value of the number that was returned by Token and returns z.
+// Decimals are rounded to the precision of z, or 64 bits if the precision of z is zero, like big.Float.Parse.
+// It returns ErrNotFinite for NaN and ErrNotNumber if the token is not a number.
+func BigFloat(z *big.Float, kind parse.Kind, value []byte) (*big.Float, error) {
+	switch kind {
+	case parse.Int64Kind:
+		return z.SetInt64(cast.ToInt64(value)), nil
+	case parse.Float64Kind:
+		f := cast.ToFloat64(value)
+		if math.IsNaN(f) {
+			return nil, ErrNotFinite
+		}
+		return z.SetFloat64(f), nil
+	case parse.DecimalKind:
+		if _, _, err := z.Parse(cast.ToString(value), 10); err != nil {
+			return nil, ErrNotNumber
+		}
+		return z, nil
+	}
+	return nil, ErrNotNumber
+}
+
+// BigRat sets z to the exact value of the number that was returned by Token and returns z.
+// It returns ErrNotFinite for NaN and Infinity and ErrNotNumber if the token is not a number.
+func BigRat(z *big.Rat, kind parse.Kind, value []byte) (*big.Rat, error) {
+	switch kind {
+	case parse.Int64Kind:
+		return z.SetInt64(cast.ToInt64(value)), nil
+	case parse.Float64Kind:
+		if z.SetFloat64(cast.ToFloat64(value)) == nil {
+			return nil, ErrNotFinite
+		}
+		return z, nil
+	case parse.DecimalKind:
+		if _, ok := z.SetString(cast.ToString(value)); !ok {
+			return nil, ErrNotNumber
+		}
+		return z, nil
+	}
+	return nil, ErrNotNumber
+}
@@ -0,0 +1,241 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package token
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/scan"
+	"github.com/katydid/parser-go/parse"
+)
+
+func bigToken(t *testing.T, input string, exact bool) (parse.Kind, []byte) {
+	t.Helper()
+	tzer := NewTokenizer([]byte(input))
+	tzer.SetExactNumbers(exact)
+	if _, err := tzer.Next(); err != nil {
+		t.Fatal(err)
+	}
+	kind, value, err := tzer.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kind, value
+}
+
+func TestBigInt(t *testing.T) {
+	ints := map[string]string{
+		"0":                               "0",
+		"-12":                             "-12",
+		"18446744073709551615":            "18446744073709551615",
+		"-123456789012345678901234567890": "-123456789012345678901234567890",
+		"1e3":                             "1000",
+		"1.0":                             "1",
+	}
+	// Without exact numbers, these are parsed as a float64 first.
+	exactInts := map[string]string{
+		"123456789012345678901234567890000e-3":    "123456789012345678901234567890",
+		"1234567890123456789012345678901234567e2": "123456789012345678901234567890123456700",
+	}
+	z := new(big.Int)
+	for _, exact := range []bool{false, true} {
+		if exact {
+			for input, want := range exactInts {
+				ints[input] = want
+			}
+		}
+		for input, want := range ints {
+			kind, value := bigToken(t, input, exact)
+			got, err := BigInt(z, kind, value)
+			if err != nil {
+				t.Fatalf("%s: %v", input, err)
+			}
+			if got != z {
+				t.Fatalf("%s: expected z to be reused", input)
+			}
+			if got.String() != want {
+				t.Fatalf("%s: want %s, but got %s", input, want, got)
+			}
+		}
+		for _, input := range []string{"1.5", "1e-3", "-0.25", `"1"`} {
+			kind, value := bigToken(t, input, exact)
+			if _, err := BigInt(z, kind, value); err == nil {
+				t.Fatalf("%s: expected error", input)
+			}
+		}
+	}
+}
+
+func TestBigFloat(t *testing.T) {
+	input := "3.14159265358979323846264338327950288419716939937510582097494459"
+	z := new(big.Float).SetPrec(200)
+	kind, value := bigToken(t, input, true)
+	if kind != parse.DecimalKind {
+		t.Fatalf("want decimal, but got %v", kind)
+	}
+	if _, err := BigFloat(z, kind, value); err != nil {
+		t.Fatal(err)
+	}
+	want, _, _ := new(big.Float).SetPrec(200).Parse(input, 10)
+	if z.Cmp(want) != 0 {
+		t.Fatalf("want %v, but got %v", want, z)
+	}
+	// Without exact numbers, the number is parsed as a float64 first.
+	kind, value = bigToken(t, input, false)
+	if _, err := BigFloat(z, kind, value); err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := z.Float64(); f != 3.141592653589793 {
+		t.Fatalf("want pi, but got %v", z)
+	}
+	// Floats that overflow a float64 are decimals.
+	kind, value = bigToken(t, "1e400", false)
+	if _, err := BigFloat(z, kind, value); err != nil {
+		t.Fatal(err)
+	}
+	if z.IsInf() || z.MantExp(nil) != 1329 {
+		t.Fatalf("want 1e400, but got %v", z)
+	}
+}
+
+func TestBigRat(t *testing.T) {
+	rats := map[string]string{
+		"0.1":                      "1/10",
+		"-2.5e-3":                  "-1/400",
+		"18446744073709551616":     "18446744073709551616/1",
+		"1e400":                    "1" + zeros(400) + "/1",
+		"12345678901234567890.125": "98765431209876543121/8",
+	}
+	z := new(big.Rat)
+	for input, want := range rats {
+		kind, value := bigToken(t, input, true)
+		got, err := BigRat(z, kind, value)
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if got.String() != want {
+			t.Fatalf("%s: want %s, but got %s", input, want, got)
+		}
+	}
+	// Without exact numbers, 0.1 is the closest float64.
+	kind, value := bigToken(t, "0.1", false)
+	if _, err := BigRat(z, kind, value); err != nil {
+		t.Fatal(err)
+	}
+	if z.String() == "1/10" {
+		t.Fatalf("expected the float64 closest to 0.1")
+	}
+}
+
+func zeros(n int) string {
+	bs := make([]byte, n)
+	for i := range bs {
+		bs[i] = '0'
+	}
+	return string(bs)
+}
+
+func TestExactNumbers(t *testing.T) {
+	inputs := []string{"0", "-1", "9223372036854775807", "1.0", "1.5e-3", "1e400", "0.30000000000000004"}
+	for _, input := range inputs {
+		kind, value := bigToken(t, input, true)
+		if kind != parse.DecimalKind || string(value) != input {
+			t.Fatalf("%s: want exact decimal, but got %v %s", input, kind, value)
+		}
+	}
+	// Uint still works for exact integers.
+	tzer := NewTokenizer([]byte("42"))
+	tzer.SetExactNumbers(true)
+	if _, err := tzer.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := tzer.Uint(); err != nil || got != 42 {
+		t.Fatalf("want 42, but got %v, %v", got, err)
+	}
+	// Hexadecimal numbers, NaN and Infinity are not decimal text.
+	tzer = NewTokenizer([]byte("[0x1F, Infinity, .5]"))
+	tzer.SetMode(scan.JSON5)
+	tzer.SetExactNumbers(true)
+	want := []parse.Kind{parse.Int64Kind, parse.Float64Kind, parse.DecimalKind}
+	for _, w := range want {
+		kind, err := tzer.Next()
+		for err == nil && !kind.IsNumber() {
+			kind, err = tzer.Next()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _, err := tzer.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != w {
+			t.Fatalf("want %v, but got %v", w, got)
+		}
+	}
+}
+
+func TestExactNumbersJSON5(t *testing.T) {
+	// JSON5 decimals are returned as JSON text.
+	inputs := map[string]string{
+		".5":     "0.5",
+		"-.5":    "-0.5",
+		"+.5e1":  "0.5e1",
+		"5.":     "5",
+		"-5.e-3": "-5e-3",
+		"+1":     "1",
+		"+1.25":  "1.25",
+		"1.25":   "1.25",
+	}
+	for input, want := range inputs {
+		tzer := NewTokenizer([]byte(input))
+		tzer.SetMode(scan.JSON5)
+		tzer.SetExactNumbers(true)
+		if _, err := tzer.Next(); err != nil {
+			t.Fatal(err)
+		}
+		kind, value, err := tzer.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if kind != parse.DecimalKind || string(value) != want {
+			t.Fatalf("%s: want decimal %s, but got %v %s", input, want, kind, value)
+		}
+	}
+}
+
+func TestBigIntNoAllocs(t *testing.T) {
+	tzer := NewTokenizer(nil)
+	tzer.SetExactNumbers(true)
+	input := []byte("123456789012345678901234567890")
+	z := new(big.Int)
+	allocs := testing.AllocsPerRun(100, func() {
+		tzer.Init(input)
+		if _, err := tzer.Next(); err != nil {
+			t.Fatal(err)
+		}
+		kind, value, err := tzer.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := BigInt(z, kind, value); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("want no allocations, but got %v", allocs)
+	}
+}
@@ -30,6 +30,9 @@ var ErrNotInt = errors.New("value is not a int")
 // ErrNotUint is an error that represents a type error.
 var ErrNotUint = errors.New("value is not a uint")
 
+// ErrNotFinite is an error that represents NaN or Infinity, which cannot be represented by a big number.
+var ErrNotFinite = errors.New("value is not a finite number")
+
 // ErrNotBool is an error that represents a type error.
 var ErrNotBool = errors.New("value is not a bool")
 
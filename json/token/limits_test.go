@@ -0,0 +1,47 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package token
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/scan"
+)
+
+func TestLimitTokens(t *testing.T) {
+	tzer := NewTokenizer([]byte(`["abc",123,"a\tcd"]`))
+	tzer.Limit(scan.Limits{MaxStringBytes: 3, MaxNumberDigits: 3})
+	expect(t, tzer.Next, scan.ArrayOpenKind)
+	expect(t, tzer.Next, scan.StringKind)
+	expectStr(t, tzer, "abc")
+	expect(t, tzer.Next, scan.CommaKind)
+	expect(t, tzer.Next, scan.NumberKind)
+	expectInt(t, tzer, 123)
+	expect(t, tzer.Next, scan.CommaKind)
+	expect(t, tzer.Next, scan.StringKind)
+	// The string is not unquoted, since it is too long.
+	allocs := 0
+	tzer.(*tokenizer).alloc = func(size int) []byte {
+		allocs++
+		return make([]byte, size)
+	}
+	if _, _, err := tzer.Token(); !errors.Is(err, scan.ErrStringTooLong) {
+		t.Fatalf("expected %v, but got %v", scan.ErrStringTooLong, err)
+	}
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, but got %d", allocs)
+	}
+}
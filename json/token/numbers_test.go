@@ -279,6 +279,48 @@ func TestNumbersMaxUint64Plus1(t *testing.T) {
 	}
 }
 
+func TestUint(t *testing.T) {
+	uints := map[string]uint64{
+		"0":                    0,
+		"9223372036854775807":  math.MaxInt64,
+		"9223372036854775808":  math.MaxInt64 + 1,
+		"10000000000000000000": 1e19,
+		"18446744073709551615": math.MaxUint64,
+	}
+	for input, want := range uints {
+		tzer := NewTokenizer([]byte(input))
+		if _, err := tzer.Next(); err != nil {
+			t.Fatal(err)
+		}
+		got, err := tzer.Uint()
+		if err != nil {
+			t.Fatalf("%s: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("%s: got %v, but want %v", input, got, want)
+		}
+	}
+	notUints := []string{
+		"-1",
+		"18446744073709551616", // math.MaxUint64 + 1
+		"99999999999999999999",
+		"-9223372036854775809",
+		"1.0",
+		"1e19",
+		`"1"`,
+		"true",
+	}
+	for _, input := range notUints {
+		tzer := NewTokenizer([]byte(input))
+		if _, err := tzer.Next(); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := tzer.Uint(); err != ErrNotUint {
+			t.Fatalf("%s: expected not a uint, but got %v, %v", input, got, err)
+		}
+	}
+}
+
 func TestNumbersMaxFloat64(t *testing.T) {
 	input := "1.79769313486231570814527423731704356798070e+308" // math.MaxFloat64
 	var want float64 = math.MaxFloat64
@@ -15,6 +15,9 @@
 package token
 
 import (
+	"bytes"
+	"io"
+
 	"github.com/katydid/parser-go-json/json/internal/fork/unquote"
 	"github.com/katydid/parser-go-json/json/scan"
 	"github.com/katydid/parser-go/cast"
@@ -29,11 +32,64 @@ type Tokenizer interface {
 	Token() (parse.Kind, []byte, error)
 	// Init restarts the tokenizer with a new byte buffer, without allocating a new tokenizer.
 	Init([]byte)
+	// InitReader restarts the tokenizer with a reader, without allocating a new tokenizer.
+	// Byte slices returned by Token are only valid until the next call to Next.
+	InitReader(io.Reader)
+	// Feed appends a chunk of input to the tokenizer.
+	// Until Close is called, Next returns scan.ErrNeedMoreInput, instead of io.EOF or a token that is cut off.
+	Feed([]byte)
+	// Close signals that no more input will be fed to the tokenizer.
+	Close()
+	// NextRecord restricts the tokenizer to the next record, which ends just before the next sep byte.
+	// It returns true if the record only contains spaces, and io.EOF if there are no more records.
+	NextRecord(sep byte) (bool, error)
+	// Peek returns the Kind of the next token, without moving to it.
+	Peek() (scan.Kind, error)
+	// Offset returns the offset in bytes of the current token from the start of the input.
+	Offset() int
+	// Position returns the offset, line and column of the current token.
+	Position() scan.Position
+	// Kind returns the Kind of the current token.
+	Kind() scan.Kind
+	// Key returns the Kind of the current token, when it is used as an object key of an object at the given depth.
+	// In JSON5 mode the keywords true, false, null, Infinity and NaN are identifiers, when they are used as an object key.
+	// When the input is read from a reader or fed, Token returns a copy of the key,
+	// which stays valid until the next key at the same or a lower depth, even though other tokens are only valid until the next call to Next.
+	Key(depth int) scan.Kind
+	// Mark marks the start of the current token, so that its bytes are kept until Raw or Unmark is called.
+	Mark()
+	// Raw scans to the end of the current token and returns the input from the start of the marked token.
+	Raw() ([]byte, error)
+	// Unmark removes the mark.
+	Unmark()
+	// Limit sets the limits of the size of the input and of its tokens, see scan.Limits.
+	// Tokens are checked before they are unquoted or parsed, so that no memory is allocated for a token that is too long.
+	Limit(scan.Limits)
+	// SetMode sets the mode of the scanner, see scan.Mode.
+	// Strings and numbers are checked by the scanner, before they are unquoted or parsed, if the mode requires it.
+	SetMode(scan.Mode)
+	// SetExactNumbers sets whether Token returns all decimal numbers as parse.DecimalKind with their exact text,
+	// instead of parsing them into an int64 or float64, which could lose precision.
+	// Hexadecimal numbers, NaN and Infinity are still parsed.
+	// JSON5 decimals are returned as JSON text, for example ".5" as "0.5", "5." as "5" and "+1" as "1".
+	SetExactNumbers(bool)
+	// Comment returns the comments before the current token, if the mode allows comments, see scan.Scanner.Comment.
+	Comment() []byte
+	// Uint tokenizes the current token and returns it as an unsigned integer, if it is an integer between 0 and math.MaxUint64.
+	// It returns ErrNotUint for any other token.
+	Uint() (uint64, error)
+	// SyntaxError returns a *scan.SyntaxError that wraps the error, with the position of the current token.
+	SyntaxError(err error, found scan.Kind, expected []scan.Kind) error
 }
 
 type tokenizer struct {
 	scanner scan.Scanner
 	alloc   func(size int) []byte
+	// limits and mode are kept, so that it can be decided which tokens need to be checked by the scanner before they are tokenized.
+	limits scan.Limits
+	mode   scan.Mode
+	// exact is true if decimal numbers are returned as their exact text, see SetExactNumbers.
+	exact bool
 
 	scanTokenStart []byte
 	skipped        bool
@@ -44,7 +100,25 @@ type tokenizer struct {
 	tokenErr    error
 	tokenDouble float64
 	tokenInt    int64
+	tokenUint   uint64
+	// tokenUintOK is true if the token is a decimal that fits in a uint64.
+	tokenUintOK bool
 	tokenBytes  []byte
+
+	// streaming is true if the input is read from a reader or fed, see InitReader and Feed.
+	// The scanner then reuses the memory of its tokens, so tokens are only valid until the next call to Next.
+	streaming bool
+	// scratch is the memory that is allocated for tokens while streaming, which is reused after each call to Next,
+	// so that the memory does not grow with the size of the input.
+	scratch []byte
+	// allocScratch is the scratchAlloc method value, which is kept, so that it is only allocated once.
+	allocScratch func(int) []byte
+	// key is true if the current token is used as an object key, see Key.
+	key      bool
+	keyDepth int
+	// keys contains the copies of the keys of all open objects while streaming and keyStarts contains their offsets by depth.
+	keys      []byte
+	keyStarts []int
 }
 
 func NewTokenizer(buf []byte) Tokenizer {
@@ -55,27 +129,163 @@ func NewTokenizer(buf []byte) Tokenizer {
 }
 
 func NewTokenizerWithCustomAllocator(buf []byte, alloc func(int) []byte) Tokenizer {
-	return &tokenizer{
+	t := &tokenizer{
 		scanner: scan.NewScanner(buf),
 		alloc:   alloc,
 		skipped: true,
+		// Without a buffer, the tokenizer waits for input to be fed.
+		streaming: buf == nil,
 	}
+	t.allocScratch = t.scratchAlloc
+	return t
 }
 
 // Init restarts the tokenizer with a new byte buffer, without allocating a new tokenizer.
 func (t *tokenizer) Init(buf []byte) {
 	t.skipped = true
+	t.streaming = false
+	t.resetKeys()
 	t.scanner.Init(buf)
 }
 
+// InitReader restarts the tokenizer with a reader, without allocating a new tokenizer.
+func (t *tokenizer) InitReader(r io.Reader) {
+	t.skipped = true
+	t.streaming = true
+	t.resetKeys()
+	t.scanner.InitReader(r)
+}
+
+// Feed appends a chunk of input to the tokenizer.
+func (t *tokenizer) Feed(buf []byte) {
+	t.streaming = true
+	t.scanner.Feed(buf)
+}
+
+// Close signals that no more input will be fed to the tokenizer.
+func (t *tokenizer) Close() {
+	t.scanner.Close()
+}
+
+// NextRecord restricts the tokenizer to the next record, skipping whatever is left of the current record.
+func (t *tokenizer) NextRecord(sep byte) (bool, error) {
+	t.skipped = true
+	t.tokenized = false
+	t.key = false
+	t.resetKeys()
+	return t.scanner.NextRecord(sep)
+}
+
+// Peek returns the Kind of the next token, without moving to it.
+func (t *tokenizer) Peek() (scan.Kind, error) {
+	if !t.skipped {
+		if _, err := t.scanner.ScanToEnd(t.scanKind); err != nil {
+			return scan.UnknownKind, err
+		}
+		t.skipped = true
+	}
+	// NextStart only skips over spaces, so the next call to Next will return the same token.
+	kind, _, err := t.scanner.NextStart()
+	return kind, err
+}
+
+// Offset returns the offset in bytes of the current token from the start of the input.
+func (t *tokenizer) Offset() int {
+	return t.scanner.Offset()
+}
+
+// Position returns the offset, line and column of the current token.
+func (t *tokenizer) Position() scan.Position {
+	return t.scanner.Position()
+}
+
+// Kind returns the Kind of the current token.
+func (t *tokenizer) Kind() scan.Kind {
+	return t.scanKind
+}
+
+// Key returns the Kind of the current token, when it is used as an object key of an object at the given depth.
+// In JSON5 mode the keywords true, false, null, Infinity and NaN are identifiers, when they are used as an object key.
+func (t *tokenizer) Key(depth int) scan.Kind {
+	t.key = true
+	t.keyDepth = depth
+	if t.mode&scan.JSON5 == 0 || t.skipped {
+		return t.scanKind
+	}
+	switch t.scanKind {
+	case scan.TrueKind, scan.FalseKind, scan.NullKind:
+		t.scanKind = scan.IdentifierKind
+	case scan.NumberKind:
+		// Infinity and NaN are the only numbers that start with a letter.
+		if c := t.scanTokenStart[0]; c == 'I' || c == 'N' {
+			t.scanKind = scan.IdentifierKind
+		}
+	}
+	return t.scanKind
+}
+
+// Mark marks the start of the current token, so that its bytes are kept until Raw or Unmark is called.
+func (t *tokenizer) Mark() {
+	t.scanner.Mark()
+}
+
+// Raw scans to the end of the current token and returns the input from the start of the marked token.
+func (t *tokenizer) Raw() ([]byte, error) {
+	if !t.skipped {
+		if _, err := t.scanner.ScanToEnd(t.scanKind); err != nil {
+			t.scanner.Unmark()
+			return nil, err
+		}
+		t.skipped = true
+	}
+	return t.scanner.Marked(), nil
+}
+
+// Unmark removes the mark.
+func (t *tokenizer) Unmark() {
+	t.scanner.Unmark()
+}
+
+// Limit sets the limits of the size of the input and of its tokens.
+func (t *tokenizer) Limit(limits scan.Limits) {
+	t.limits = limits
+	t.scanner.Limit(limits)
+}
+
+// SetMode sets the mode of the scanner.
+func (t *tokenizer) SetMode(mode scan.Mode) {
+	t.mode = mode
+	t.scanner.SetMode(mode)
+}
+
+// SetExactNumbers sets whether Token returns all decimal numbers as their exact text.
+func (t *tokenizer) SetExactNumbers(exact bool) {
+	t.exact = exact
+}
+
+// Comment returns the comments before the current token.
+func (t *tokenizer) Comment() []byte {
+	return t.scanner.Comment()
+}
+
+// SyntaxError returns a *scan.SyntaxError that wraps the error, with the position of the current token.
+func (t *tokenizer) SyntaxError(err error, found scan.Kind, expected []scan.Kind) error {
+	return t.scanner.SyntaxError(err, found, expected)
+}
+
 // Next returns the Kind of the token or an error.
 func (t *tokenizer) Next() (scan.Kind, error) {
 	if !t.skipped {
 		if _, err := t.scanner.ScanToEnd(t.scanKind); err != nil {
-			return scan.UnknownKind, nil
+			return scan.UnknownKind, err
 		}
+		// If NextStart needs more input, the previous token should not be scanned again.
+		t.skipped = true
 	}
 	t.tokenized = false
+	t.key = false
+	// The tokens that were allocated while streaming are only valid until the next call to Next.
+	t.scratch = t.scratch[:0]
 	kind, token, err := t.scanner.NextStart()
 	if err != nil {
 		return scan.UnknownKind, err
@@ -87,16 +297,36 @@ func (t *tokenizer) Next() (scan.Kind, error) {
 }
 
 func (t *tokenizer) tokenizeNumber() error {
-	offset, intval, intok, floatval, floatok, decimalok := scan.ParseNumber(t.scanTokenStart)
-	if err := t.scanner.Skip(offset); err != nil {
+	if err := t.scanFirst(); err != nil {
 		return err
 	}
-	t.skipped = true
+	offset, intval, intok, uintval, uintok, floatval, floatok, decimalok := scan.ParseNumberUint(t.scanTokenStart, t.mode)
+	if err := t.skip(offset); err != nil {
+		return err
+	}
+	if t.exact && (intok || uintok || floatok || decimalok) && isDecimalText(t.scanTokenStart[:offset]) {
+		if intok && intval >= 0 {
+			uintval, uintok = uint64(intval), true
+		}
+		t.tokenKind = parse.DecimalKind
+		t.tokenBytes = t.decimalText(t.scanTokenStart[:offset])
+		t.tokenUint = uintval
+		t.tokenUintOK = uintok
+		return nil
+	}
 	if intok {
 		t.tokenKind = parse.Int64Kind
 		t.tokenInt = intval
 		return nil
 	}
+	if uintok {
+		// There is no unsigned integer kind, so it is returned as a decimal by Token and as a uint64 by Uint.
+		t.tokenKind = parse.DecimalKind
+		t.tokenBytes = t.decimalText(t.scanTokenStart[:offset])
+		t.tokenUint = uintval
+		t.tokenUintOK = true
+		return nil
+	}
 	if floatok {
 		t.tokenKind = parse.Float64Kind
 		t.tokenDouble = floatval
@@ -104,10 +334,64 @@ func (t *tokenizer) tokenizeNumber() error {
 	}
 	if decimalok {
 		t.tokenKind = parse.DecimalKind
-		t.tokenBytes = t.scanTokenStart[:offset]
+		t.tokenBytes = t.decimalText(t.scanTokenStart[:offset])
 		return nil
 	}
-	return ErrNotNumber
+	return t.scanner.SyntaxError(ErrNotNumber, t.scanKind, nil)
+}
+
+// decimalText returns the text of a decimal number as JSON text.
+// JSON5 also allows a leading '+' and a leading or trailing decimal point,
+// which are rewritten, so that a decimal can always be written back as JSON, for example ".5" as "0.5" and "+5." as "5".
+func (t *tokenizer) decimalText(number []byte) []byte {
+	if t.mode&scan.JSON5 == 0 {
+		return number
+	}
+	rest := number
+	if rest[0] == '+' || rest[0] == '-' {
+		rest = rest[1:]
+	}
+	leading := rest[0] == '.'
+	dot := bytes.IndexByte(rest, '.')
+	trailing := dot >= 0 && (dot+1 == len(rest) || rest[dot+1] == 'e' || rest[dot+1] == 'E')
+	if number[0] != '+' && !leading && !trailing {
+		return number
+	}
+	size := len(rest)
+	if number[0] == '-' {
+		size++
+	}
+	if leading {
+		size++
+	}
+	if trailing {
+		size--
+	}
+	text := t.allocator()(size)[:0]
+	if number[0] == '-' {
+		text = append(text, '-')
+	}
+	if leading {
+		text = append(text, '0')
+	}
+	if trailing {
+		text = append(text, rest[:dot]...)
+		return append(text, rest[dot+1:]...)
+	}
+	return append(text, rest...)
+}
+
+// isDecimalText returns true if the number is written in decimal notation,
+// which excludes hexadecimal numbers, NaN and Infinity.
+func isDecimalText(number []byte) bool {
+	for _, c := range number {
+		switch c {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '+', '-', '.', 'e', 'E':
+		default:
+			return false
+		}
+	}
+	return true
 }
 
 func unquoteBytes(alloc func(int) []byte, s []byte) ([]byte, int, error) {
@@ -118,15 +402,50 @@ func unquoteBytes(alloc func(int) []byte, s []byte) ([]byte, int, error) {
 	return u, offset, nil
 }
 
+func unquoteJSON5Bytes(alloc func(int) []byte, s []byte) ([]byte, int, error) {
+	u, offset, ok := unquote.UnquoteJSON5(alloc, s)
+	if !ok {
+		return nil, 0, errUnquote
+	}
+	return u, offset, nil
+}
+
 func (t *tokenizer) tokenizeString() error {
-	res, offset, err := unquoteBytes(t.alloc, t.scanTokenStart)
+	if err := t.scanFirst(); err != nil {
+		return err
+	}
+	unquoteString := unquoteBytes
+	if t.mode&scan.JSON5 != 0 {
+		unquoteString = unquoteJSON5Bytes
+	}
+	res, offset, err := unquoteString(t.allocator(), t.scanTokenStart)
 	if err != nil {
+		return t.scanner.SyntaxError(err, t.scanKind, nil)
+	}
+	if err := t.skip(offset); err != nil {
 		return err
 	}
-	if err := t.scanner.Skip(offset); err != nil {
+	t.tokenBytes = res
+	t.tokenKind = parse.StringKind
+	return nil
+}
+
+// tokenizeIdentifier tokenizes a JSON5 identifier as a string.
+func (t *tokenizer) tokenizeIdentifier() error {
+	if err := t.scanFirst(); err != nil {
+		return err
+	}
+	offset, err := scan.Identifier(t.scanTokenStart)
+	if err != nil {
+		return t.scanner.SyntaxError(err, t.scanKind, nil)
+	}
+	res, ok := unquote.UnquoteIdentifier(t.allocator(), t.scanTokenStart[:offset])
+	if !ok {
+		return t.scanner.SyntaxError(errUnquote, t.scanKind, nil)
+	}
+	if err := t.skip(offset); err != nil {
 		return err
 	}
-	t.skipped = true
 	t.tokenBytes = res
 	t.tokenKind = parse.StringKind
 	return nil
@@ -137,10 +456,10 @@ func (t *tokenizer) Token() (parse.Kind, []byte, error) {
 		return parse.UnknownKind, nil, err
 	}
 	if t.tokenKind == parse.Int64Kind {
-		return t.tokenKind, cast.FromInt64(t.tokenInt, t.alloc), nil
+		return t.tokenKind, cast.FromInt64(t.tokenInt, t.allocator()), nil
 	}
 	if t.tokenKind == parse.Float64Kind {
-		return t.tokenKind, cast.FromFloat64(t.tokenDouble, t.alloc), nil
+		return t.tokenKind, cast.FromFloat64(t.tokenDouble, t.allocator()), nil
 	}
 	return t.tokenKind, t.tokenBytes, nil
 }
@@ -159,6 +478,23 @@ func (t *tokenizer) Int() (int64, error) {
 	return 0, ErrNotInt
 }
 
+// Uint tokenizes the current token and returns it as an unsigned integer,
+// if it is an integer between 0 and math.MaxUint64.
+// Integers larger than math.MaxInt64 are returned by Token as a parse.DecimalKind, but are parsed into a uint64 without a round trip through their text.
+// It returns ErrNotUint for any other token.
+func (t *tokenizer) Uint() (uint64, error) {
+	if err := t.tokenize(); err != nil {
+		return 0, err
+	}
+	if t.tokenUintOK {
+		return t.tokenUint, nil
+	}
+	if t.tokenKind == parse.Int64Kind && t.tokenInt >= 0 {
+		return uint64(t.tokenInt), nil
+	}
+	return 0, ErrNotUint
+}
+
 func (t *tokenizer) Double() (float64, error) {
 	if t.tokenKind == parse.Float64Kind {
 		return t.tokenDouble, nil
@@ -173,26 +509,116 @@ func (t *tokenizer) Bytes() ([]byte, error) {
 	return nil, ErrNotBytes
 }
 
+// skip moves the scanner past the current token, which was tokenized up to the offset,
+// unless the scanner has already scanned to the end of the token.
+func (t *tokenizer) skip(offset int) error {
+	if t.skipped {
+		return nil
+	}
+	if err := t.scanner.Skip(offset); err != nil {
+		return err
+	}
+	t.skipped = true
+	return nil
+}
+
+// scanFirst scans to the end of a string or number, if the scanner needs to check it before it is unquoted or parsed,
+// for example, because its size is limited or because strings need to be valid UTF-8.
+func (t *tokenizer) scanFirst() error {
+	if t.skipped || !t.checked() {
+		return nil
+	}
+	token, err := t.scanner.ScanToEnd(t.scanKind)
+	if err != nil {
+		return err
+	}
+	t.scanTokenStart = token
+	t.skipped = true
+	return nil
+}
+
+// checked returns true if the scanner checks more than the syntax of the current string or number.
+func (t *tokenizer) checked() bool {
+	switch t.scanKind {
+	case scan.StringKind:
+		return t.limits.MaxStringBytes > 0 || t.mode&scan.StrictUTF8 != 0
+	case scan.IdentifierKind:
+		return t.limits.MaxStringBytes > 0
+	case scan.NumberKind:
+		return t.limits.MaxNumberDigits > 0 || t.mode&scan.SafeIntegers != 0
+	}
+	return false
+}
+
+// allocator returns the allocator for the memory of the current token.
+func (t *tokenizer) allocator() func(int) []byte {
+	if t.streaming {
+		return t.allocScratch
+	}
+	return t.alloc
+}
+
+// scratchAlloc allocates the memory of a token from the scratch memory, which is reused after the next call to Next.
+func (t *tokenizer) scratchAlloc(size int) []byte {
+	n := len(t.scratch)
+	if n+size > cap(t.scratch) {
+		// Tokens that were already returned keep the old memory alive, until they are no longer used.
+		t.scratch = make([]byte, 0, max(2*cap(t.scratch), size, 64))
+		n = 0
+	}
+	t.scratch = t.scratch[:n+size]
+	return t.scratch[n : n+size : n+size]
+}
+
+// keepKey copies the current key, so that it stays valid until the next key at the same or a lower depth, see Key.
+// The keys of deeper objects are closed, so their memory is reused.
+func (t *tokenizer) keepKey() {
+	if t.keyDepth < len(t.keyStarts) {
+		t.keys = t.keys[:t.keyStarts[t.keyDepth]]
+		t.keyStarts = t.keyStarts[:t.keyDepth]
+	}
+	for len(t.keyStarts) < t.keyDepth {
+		// The keys of the objects around this object were not tokenized, so they are empty.
+		t.keyStarts = append(t.keyStarts, len(t.keys))
+	}
+	start := len(t.keys)
+	t.keyStarts = append(t.keyStarts, start)
+	t.keys = append(t.keys, t.tokenBytes...)
+	t.tokenBytes = t.keys[start:len(t.keys):len(t.keys)]
+}
+
+func (t *tokenizer) resetKeys() {
+	t.keys = t.keys[:0]
+	t.keyStarts = t.keyStarts[:0]
+}
+
 func (t *tokenizer) tokenize() error {
 	if !t.tokenized {
+		t.tokenUintOK = false
 		var err error
 		switch t.scanKind {
 		case scan.StringKind:
 			err = t.tokenizeString()
 		case scan.NumberKind:
 			err = t.tokenizeNumber()
+		case scan.IdentifierKind:
+			err = t.tokenizeIdentifier()
 		case scan.TrueKind:
 			t.tokenKind = parse.TrueKind
+			t.tokenBytes = nil
 		case scan.FalseKind:
 			t.tokenKind = parse.FalseKind
+			t.tokenBytes = nil
 		case scan.NullKind:
 			t.tokenKind = parse.NullKind
+			t.tokenBytes = nil
 		}
-		t.tokenized = true
-		if err != nil {
-			t.tokenErr = err
-			return err
+		if err == nil && t.key && t.streaming && t.tokenKind == parse.StringKind {
+			t.keepKey()
 		}
+		t.tokenized = true
+		// Also clear the error of a previous token.
+		t.tokenErr = err
 	}
 	return t.tokenErr
 }
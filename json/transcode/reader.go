@@ -0,0 +1,149 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package transcode
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// defaultBufferSize is the size of the buffer that the underlying reader is read into.
+const defaultBufferSize = 4096
+
+// maxEmptyReads is the number of times a reader may return no bytes and no error, before we give up.
+const maxEmptyReads = 100
+
+// Reader wraps a reader of JSON text, detects its encoding and transcodes it to UTF-8, without its byte order mark.
+// It can be passed to InitReader of a parser.
+// Offsets that are reported by the parser are offsets in the transcoded UTF-8 text.
+type Reader struct {
+	r        io.Reader
+	encoding Encoding
+	detected bool
+
+	// buf contains the bytes that were read, but not transcoded yet, in buf[start:end].
+	// It is reused on the next call to Reset.
+	buf   []byte
+	start int
+	end   int
+	// err is the error that was returned by the underlying reader, which is returned once buf is empty.
+	err error
+
+	// pending contains the rest of a character that did not fit into the caller's buffer.
+	pending      [utf8.UTFMax]byte
+	pendingStart int
+	pendingEnd   int
+}
+
+// NewReader returns a Reader that transcodes the text that is read from r to UTF-8.
+func NewReader(r io.Reader) *Reader {
+	t := &Reader{}
+	t.Reset(r)
+	return t
+}
+
+// Reset restarts the Reader with a new underlying reader, without allocating a new buffer.
+func (t *Reader) Reset(r io.Reader) {
+	if t.buf == nil {
+		t.buf = make([]byte, defaultBufferSize)
+	}
+	t.r = r
+	t.encoding = UTF8
+	t.detected = false
+	t.start = 0
+	t.end = 0
+	t.err = nil
+	t.pendingStart = 0
+	t.pendingEnd = 0
+}
+
+// Encoding returns the encoding that was detected, which is only known after the first call to Read.
+func (t *Reader) Encoding() Encoding {
+	return t.encoding
+}
+
+// Read reads transcoded UTF-8 text into p.
+func (t *Reader) Read(p []byte) (int, error) {
+	if !t.detected {
+		for t.end < 4 && t.err == nil {
+			t.fill()
+		}
+		var bom int
+		t.encoding, bom = Detect(t.buf[:t.end])
+		t.start = bom
+		t.detected = true
+	}
+	n := copy(p, t.pending[t.pendingStart:t.pendingEnd])
+	t.pendingStart += n
+	if t.encoding == UTF8 {
+		if t.start < t.end {
+			m := copy(p[n:], t.buf[t.start:t.end])
+			t.start += m
+			return n + m, nil
+		}
+		if n > 0 {
+			return n, nil
+		}
+		if t.err != nil {
+			return 0, t.err
+		}
+		return t.r.Read(p)
+	}
+	for n < len(p) {
+		r, size := decode(t.encoding, t.buf[t.start:t.end], t.err != nil)
+		if size == 0 {
+			if n > 0 {
+				return n, nil
+			}
+			if t.err != nil {
+				if t.start < t.end {
+					// The text ends with an incomplete character.
+					t.start = t.end
+					t.err = io.ErrUnexpectedEOF
+				}
+				return 0, t.err
+			}
+			t.fill()
+			continue
+		}
+		t.start += size
+		if utf8.RuneLen(r) <= len(p)-n {
+			n += utf8.EncodeRune(p[n:], r)
+			continue
+		}
+		t.pendingEnd = utf8.EncodeRune(t.pending[:], r)
+		t.pendingStart = copy(p[n:], t.pending[:t.pendingEnd])
+		n += t.pendingStart
+	}
+	return n, nil
+}
+
+// fill moves the bytes that were not transcoded yet to the front of the buffer and reads more bytes after them.
+func (t *Reader) fill() {
+	t.end = copy(t.buf, t.buf[t.start:t.end])
+	t.start = 0
+	for range maxEmptyReads {
+		n, err := t.r.Read(t.buf[t.end:])
+		t.end += n
+		if err != nil {
+			t.err = err
+			return
+		}
+		if n > 0 {
+			return
+		}
+	}
+	t.err = io.ErrNoProgress
+}
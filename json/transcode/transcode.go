@@ -0,0 +1,174 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package transcode detects whether JSON text is encoded in UTF-8, UTF-16 or UTF-32, as described in RFC 4627 section 3,
+// and transcodes it to UTF-8, so that it can be scanned.
+package transcode
+
+import (
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is the Unicode encoding of JSON text.
+type Encoding uint8
+
+const (
+	UTF8 Encoding = iota
+	UTF16BE
+	UTF16LE
+	UTF32BE
+	UTF32LE
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case UTF8:
+		return "UTF-8"
+	case UTF16BE:
+		return "UTF-16BE"
+	case UTF16LE:
+		return "UTF-16LE"
+	case UTF32BE:
+		return "UTF-32BE"
+	case UTF32LE:
+		return "UTF-32LE"
+	}
+	return "unknown"
+}
+
+// Detect returns the encoding of the JSON text and the length of its byte order mark, if it starts with one.
+// Without a byte order mark, the encoding is detected from the pattern of zero bytes in the first four bytes,
+// since the first two characters of JSON text are always ASCII:
+//
+//	00 00 00 xx  UTF-32BE
+//	00 xx 00 xx  UTF-16BE
+//	xx 00 00 00  UTF-32LE
+//	xx 00 xx 00  UTF-16LE
+//	xx xx xx xx  UTF-8
+//
+// Text that is shorter than four bytes is detected from its first two bytes.
+func Detect(buf []byte) (Encoding, int) {
+	switch {
+	case len(buf) >= 4 && buf[0] == 0x00 && buf[1] == 0x00 && buf[2] == 0xFE && buf[3] == 0xFF:
+		return UTF32BE, 4
+	case len(buf) >= 4 && buf[0] == 0xFF && buf[1] == 0xFE && buf[2] == 0x00 && buf[3] == 0x00:
+		return UTF32LE, 4
+	case len(buf) >= 2 && buf[0] == 0xFE && buf[1] == 0xFF:
+		return UTF16BE, 2
+	case len(buf) >= 2 && buf[0] == 0xFF && buf[1] == 0xFE:
+		return UTF16LE, 2
+	case len(buf) >= 3 && buf[0] == 0xEF && buf[1] == 0xBB && buf[2] == 0xBF:
+		return UTF8, 3
+	case len(buf) >= 4 && buf[0] == 0x00 && buf[1] == 0x00 && buf[2] == 0x00:
+		return UTF32BE, 0
+	case len(buf) >= 4 && buf[1] == 0x00 && buf[2] == 0x00 && buf[3] == 0x00:
+		return UTF32LE, 0
+	case len(buf) >= 2 && buf[0] == 0x00:
+		return UTF16BE, 0
+	case len(buf) >= 2 && buf[1] == 0x00:
+		return UTF16LE, 0
+	}
+	return UTF8, 0
+}
+
+// decode decodes the first character of src, which is encoded in UTF-16 or UTF-32, and returns it with its size in bytes.
+// It returns a size of zero if src does not contain the whole character yet.
+// Unpaired surrogates and invalid code points are decoded as utf8.RuneError, just like invalid UTF-8 is when strings are unquoted.
+func decode(e Encoding, src []byte, atEOF bool) (rune, int) {
+	switch e {
+	case UTF16BE, UTF16LE:
+		if len(src) < 2 {
+			return 0, 0
+		}
+		r1 := unit16(e, src)
+		if !utf16.IsSurrogate(r1) {
+			return r1, 2
+		}
+		if r1 >= 0xDC00 {
+			// A low surrogate without a high surrogate.
+			return utf8.RuneError, 2
+		}
+		if len(src) < 4 {
+			if atEOF {
+				return utf8.RuneError, 2
+			}
+			return 0, 0
+		}
+		r := utf16.DecodeRune(r1, unit16(e, src[2:]))
+		if r == utf8.RuneError {
+			// The high surrogate is not followed by a low surrogate, which is decoded next.
+			return utf8.RuneError, 2
+		}
+		return r, 4
+	case UTF32BE, UTF32LE:
+		if len(src) < 4 {
+			return 0, 0
+		}
+		var u uint32
+		if e == UTF32BE {
+			u = uint32(src[0])<<24 | uint32(src[1])<<16 | uint32(src[2])<<8 | uint32(src[3])
+		} else {
+			u = uint32(src[3])<<24 | uint32(src[2])<<16 | uint32(src[1])<<8 | uint32(src[0])
+		}
+		if u > utf8.MaxRune || !utf8.ValidRune(rune(u)) {
+			return utf8.RuneError, 4
+		}
+		return rune(u), 4
+	}
+	return utf8.DecodeRune(src)
+}
+
+func unit16(e Encoding, src []byte) rune {
+	if e == UTF16BE {
+		return rune(src[0])<<8 | rune(src[1])
+	}
+	return rune(src[1])<<8 | rune(src[0])
+}
+
+// AppendUTF8 appends the text, which is encoded using the given encoding, to dst as UTF-8.
+// It returns io.ErrUnexpectedEOF if the text ends with an incomplete character.
+func AppendUTF8(dst []byte, src []byte, e Encoding) ([]byte, error) {
+	if e == UTF8 {
+		return append(dst, src...), nil
+	}
+	for len(src) > 0 {
+		r, size := decode(e, src, true)
+		if size == 0 {
+			return dst, io.ErrUnexpectedEOF
+		}
+		dst = utf8.AppendRune(dst, r)
+		src = src[size:]
+	}
+	return dst, nil
+}
+
+// Transcoder transcodes complete inputs to UTF-8, reusing its buffer for every input.
+type Transcoder struct {
+	buf []byte
+}
+
+// Bytes detects the encoding of the input and returns it as UTF-8, without its byte order mark.
+// UTF-8 input is returned without copying.
+// Otherwise the returned bytes are only valid until the next call to Bytes.
+func (t *Transcoder) Bytes(src []byte) ([]byte, error) {
+	e, bom := Detect(src)
+	if e == UTF8 {
+		return src[bom:], nil
+	}
+	var err error
+	t.buf, err = AppendUTF8(t.buf[:0], src[bom:], e)
+	return t.buf, err
+}
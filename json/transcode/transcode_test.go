@@ -0,0 +1,197 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package transcode
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/katydid/parser-go-json/json"
+	"github.com/katydid/parser-go/expect"
+	"github.com/katydid/parser-go/parse"
+)
+
+// encode encodes UTF-8 text using the given encoding.
+func encode(s string, e Encoding) []byte {
+	var buf []byte
+	for _, r := range s {
+		switch e {
+		case UTF8:
+			buf = append(buf, string(r)...)
+		case UTF16BE, UTF16LE:
+			units := []uint16{uint16(r)}
+			if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError {
+				units = []uint16{uint16(r1), uint16(r2)}
+			}
+			for _, u := range units {
+				if e == UTF16BE {
+					buf = append(buf, byte(u>>8), byte(u))
+				} else {
+					buf = append(buf, byte(u), byte(u>>8))
+				}
+			}
+		case UTF32BE:
+			buf = append(buf, byte(r>>24), byte(r>>16), byte(r>>8), byte(r))
+		case UTF32LE:
+			buf = append(buf, byte(r), byte(r>>8), byte(r>>16), byte(r>>24))
+		}
+	}
+	return buf
+}
+
+var encodings = []Encoding{UTF8, UTF16BE, UTF16LE, UTF32BE, UTF32LE}
+
+var texts = []string{
+	`{"a":[1,2.5,true,null],"b":"é"}`,
+	`"é € 𝄞"`,
+	`1`,
+	`12`,
+	` []`,
+	`{}`,
+}
+
+func TestDetect(t *testing.T) {
+	for _, e := range encodings {
+		for _, text := range texts {
+			if got, bom := Detect(encode(text, e)); got != e || bom != 0 {
+				t.Fatalf("%s in %v: detected %v with byte order mark of %d bytes", text, e, got, bom)
+			}
+			withBOM := encode("\uFEFF"+text, e)
+			want := len(withBOM) - len(encode(text, e))
+			if got, bom := Detect(withBOM); got != e || bom != want {
+				t.Fatalf("%s in %v with byte order mark: detected %v with byte order mark of %d bytes", text, e, got, bom)
+			}
+		}
+	}
+}
+
+func TestTranscoder(t *testing.T) {
+	transcoder := &Transcoder{}
+	for _, e := range encodings {
+		for _, text := range texts {
+			for _, input := range []string{text, "\uFEFF" + text} {
+				got, err := transcoder.Bytes(encode(input, e))
+				if err != nil {
+					t.Fatalf("%s in %v: %v", input, e, err)
+				}
+				if string(got) != text {
+					t.Fatalf("%s in %v: got %s", input, e, got)
+				}
+			}
+		}
+	}
+}
+
+func TestTranscoderInvalid(t *testing.T) {
+	transcoder := &Transcoder{}
+	// An unpaired high surrogate, an unpaired low surrogate and a high surrogate at the end.
+	got, err := transcoder.Bytes([]byte{'"', 0, 0x00, 0xD8, 'a', 0, 0x00, 0xDC, '"', 0, 0x00, 0xD8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\"\uFFFDa\uFFFD\"\uFFFD"; string(got) != want {
+		t.Fatalf("want %q, but got %q", want, got)
+	}
+	if _, err := transcoder.Bytes([]byte{'1', 0, '2'}); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected unexpected EOF, but got %v", err)
+	}
+	if _, err := transcoder.Bytes([]byte{0, 0, 0, '1', 0, 0}); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected unexpected EOF, but got %v", err)
+	}
+}
+
+func TestReader(t *testing.T) {
+	r := NewReader(nil)
+	for _, e := range encodings {
+		for _, text := range texts {
+			for _, input := range []string{text, "\uFEFF" + text} {
+				// One byte at a time, for both the underlying reader and the caller's buffer,
+				// so that characters are cut off on both sides.
+				r.Reset(iotest.OneByteReader(bytes.NewReader(encode(input, e))))
+				got, err := io.ReadAll(iotest.OneByteReader(r))
+				if err != nil {
+					t.Fatalf("%s in %v: %v", input, e, err)
+				}
+				if string(got) != text {
+					t.Fatalf("%s in %v: got %s", input, e, got)
+				}
+				if r.Encoding() != e {
+					t.Fatalf("%s: want encoding %v, but got %v", input, e, r.Encoding())
+				}
+				if err := iotest.TestReader(NewReader(bytes.NewReader(encode(input, e))), []byte(text)); err != nil {
+					t.Fatalf("%s in %v: %v", input, e, err)
+				}
+			}
+		}
+	}
+}
+
+func TestReaderLarge(t *testing.T) {
+	text := `[` + strings.Repeat(`"𝄞",`, 2*defaultBufferSize) + `1]`
+	for _, e := range encodings {
+		got, err := io.ReadAll(NewReader(bytes.NewReader(encode(text, e))))
+		if err != nil {
+			t.Fatalf("%v: %v", e, err)
+		}
+		if string(got) != text {
+			t.Fatalf("%v: transcoded text does not match", e)
+		}
+	}
+}
+
+func TestReaderTruncated(t *testing.T) {
+	r := NewReader(bytes.NewReader([]byte{'1', 0, '2'}))
+	if _, err := io.ReadAll(r); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected unexpected EOF, but got %v", err)
+	}
+}
+
+func TestParseUTF16(t *testing.T) {
+	// As exported by a Windows tool, in UTF-16LE with a byte order mark.
+	input := encode("\uFEFF{\"name\": \"Zoë\"}", UTF16LE)
+	p := json.NewParser()
+	p.InitReader(NewReader(bytes.NewReader(input)))
+	expect.Hint(t, p, parse.EnterHint)
+	expect.Hint(t, p, parse.FieldHint)
+	expect.String(t, p, "name")
+	expect.Hint(t, p, parse.ValueHint)
+	expect.String(t, p, "Zoë")
+	expect.Hint(t, p, parse.LeaveHint)
+	expect.EOF(t, p)
+}
+
+func TestReaderNoAllocs(t *testing.T) {
+	input := encode(texts[0], UTF16LE)
+	src := bytes.NewReader(input)
+	r := NewReader(src)
+	buf := make([]byte, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		src.Reset(input)
+		r.Reset(src)
+		for {
+			if _, err := r.Read(buf); err != nil {
+				break
+			}
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("want no allocations, but got %v", allocs)
+	}
+}
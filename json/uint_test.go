@@ -0,0 +1,96 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package json
+
+import (
+	"math"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go/expect"
+	goparse "github.com/katydid/parser-go/parse"
+)
+
+func expectUint(t *testing.T, p Parser, want uint64) {
+	t.Helper()
+	got, err := p.Uint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("want %d, but got %d", want, got)
+	}
+}
+
+func TestUint(t *testing.T) {
+	p := NewParser()
+	p.Init([]byte(`{"id":18446744073709551615,"ids":[9223372036854775808,-1]}`))
+	expect.Hint(t, p, goparse.EnterHint)
+	expect.Hint(t, p, goparse.FieldHint)
+	if _, err := p.Uint(); err != parse.ErrNotUint {
+		t.Fatalf("expected a field name not to be a uint, but got %v", err)
+	}
+	expect.Hint(t, p, goparse.ValueHint)
+	expectUint(t, p, math.MaxUint64)
+	// Token still returns the decimal text.
+	kind, value, err := p.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != goparse.DecimalKind || string(value) != "18446744073709551615" {
+		t.Fatalf("want decimal, but got %v %s", kind, value)
+	}
+	expect.Hint(t, p, goparse.FieldHint)
+	expect.Hint(t, p, goparse.EnterHint)
+	// Array indexes are unsigned integers.
+	expect.Hint(t, p, goparse.FieldHint)
+	expectUint(t, p, 0)
+	expect.Hint(t, p, goparse.ValueHint)
+	expectUint(t, p, math.MaxInt64+1)
+	expect.Hint(t, p, goparse.FieldHint)
+	expectUint(t, p, 1)
+	expect.Hint(t, p, goparse.ValueHint)
+	if _, err := p.Uint(); err != parse.ErrNotUint {
+		t.Fatalf("expected -1 not to be a uint, but got %v", err)
+	}
+}
+
+func TestUintTag(t *testing.T) {
+	p := NewJSONSchemaParser()
+	p.Init([]byte(`[1]`))
+	expect.Hint(t, p, goparse.EnterHint)
+	expect.Hint(t, p, goparse.FieldHint)
+	// The tagger returns the same error as the parser.
+	if _, err := p.Uint(); err != parse.ErrNotUint {
+		t.Fatalf("expected the array tag not to be a uint, but got %v", err)
+	}
+}
+
+func TestUintNoAllocs(t *testing.T) {
+	input := []byte(`[18446744073709551615]`)
+	p := NewParser()
+	allocs := testing.AllocsPerRun(100, func() {
+		p.Init(input)
+		p.Next()
+		p.Next()
+		p.Next()
+		if _, err := p.Uint(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("want no allocations, but got %v", allocs)
+	}
+}
@@ -0,0 +1,225 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package write
+
+import (
+	"encoding/base64"
+	"io"
+
+	"github.com/katydid/parser-go-json/json/internal/fork/strconv"
+	"github.com/katydid/parser-go-json/json/jsonschema"
+	"github.com/katydid/parser-go/cast"
+	"github.com/katydid/parser-go/parse"
+)
+
+var objectTag = []byte("object")
+
+var arrayTag = []byte("array")
+
+// Encode walks the parser, using Next and Token, and writes the JSON text of the value that it parses to dst.
+// The parser can be any parse.Parser, including one that was created by the tag package:
+//   - objects that wrap a value with an "object" or "array" parse.TagKind field are unwrapped, see tag.WithTags.
+//   - fields with parse.Int64Kind names are array indexes, see tag.WithIndexes, unless the parser says that it is an object, see jsonschema.JSONSchemaAble.
+//
+// Without tags, an empty array can only be distinguished from an empty object, if the parser implements jsonschema.JSONSchemaAble.
+// Otherwise it is written as an empty object.
+func Encode(dst io.Writer, p parse.Parser) error {
+	w := NewWriter(dst)
+	if err := EncodeValue(w, p); err != nil {
+		return err
+	}
+	if _, err := p.Next(); err != io.EOF {
+		if err == nil {
+			return ErrUnexpectedHint
+		}
+		return err
+	}
+	return w.Flush()
+}
+
+// EncodeValue writes the next value of the parser to w, see Encode.
+func EncodeValue(w Writer, p parse.Parser) error {
+	hint, err := p.Next()
+	if err != nil {
+		return err
+	}
+	return encode(w, p, hint)
+}
+
+func encode(w Writer, p parse.Parser, hint parse.Hint) error {
+	switch hint {
+	case parse.ValueHint:
+		kind, value, err := p.Token()
+		if err != nil {
+			return err
+		}
+		return encodeToken(w, kind, value)
+	case parse.EnterHint:
+		return encodeEnter(w, p)
+	}
+	return ErrUnexpectedHint
+}
+
+// encodeEnter decides whether the object or array that was entered is an object, an array or a tagged value.
+func encodeEnter(w Writer, p parse.Parser) error {
+	typ := jsonschema.JSONSchemaTypeUnknown
+	if s, ok := p.(jsonschema.JSONSchemaAble); ok {
+		typ = s.JSONSchemaType()
+	}
+	hint, err := p.Next()
+	if err != nil {
+		return err
+	}
+	switch hint {
+	case parse.FieldHint:
+		kind, name, err := p.Token()
+		if err != nil {
+			return err
+		}
+		if kind == parse.TagKind && typ != jsonschema.JSONSchemaTypeArray {
+			return encodeTagged(w, p, name)
+		}
+		if kind == parse.Int64Kind && typ != jsonschema.JSONSchemaTypeObject {
+			return encodeArray(w, p, hint)
+		}
+		return encodeObject(w, p, hint)
+	case parse.LeaveHint:
+		if typ == jsonschema.JSONSchemaTypeArray {
+			return encodeArray(w, p, hint)
+		}
+		return encodeObject(w, p, hint)
+	}
+	return encodeArray(w, p, hint)
+}
+
+// encodeTagged encodes the value of the object that wraps a tagged object or array, for example {"array": [1,2]}.
+func encodeTagged(w Writer, p parse.Parser, tag []byte) error {
+	array := string(tag) == string(arrayTag)
+	if !array && string(tag) != string(objectTag) {
+		return ErrUnsupportedKind
+	}
+	hint, err := p.Next()
+	if err != nil {
+		return err
+	}
+	if hint != parse.EnterHint {
+		return ErrUnexpectedHint
+	}
+	hint, err = p.Next()
+	if err != nil {
+		return err
+	}
+	if array {
+		err = encodeArray(w, p, hint)
+	} else {
+		err = encodeObject(w, p, hint)
+	}
+	if err != nil {
+		return err
+	}
+	// Leave the object that wrapped the tagged value.
+	hint, err = p.Next()
+	if err != nil {
+		return err
+	}
+	if hint != parse.LeaveHint {
+		return ErrUnexpectedHint
+	}
+	return nil
+}
+
+// encodeObject encodes the fields of an object, starting at the first hint after the object was entered.
+func encodeObject(w Writer, p parse.Parser, hint parse.Hint) error {
+	if err := w.WriteObjectStart(); err != nil {
+		return err
+	}
+	for hint != parse.LeaveHint {
+		if hint != parse.FieldHint {
+			return ErrUnexpectedHint
+		}
+		kind, name, err := p.Token()
+		if err != nil {
+			return err
+		}
+		if err := encodeKey(w, kind, name); err != nil {
+			return err
+		}
+		if err := EncodeValue(w, p); err != nil {
+			return err
+		}
+		if hint, err = p.Next(); err != nil {
+			return err
+		}
+	}
+	return w.WriteObjectEnd()
+}
+
+// encodeArray encodes the elements of an array, starting at the first hint after the array was entered.
+// Elements may be preceded by an index.
+func encodeArray(w Writer, p parse.Parser, hint parse.Hint) error {
+	if err := w.WriteArrayStart(); err != nil {
+		return err
+	}
+	var err error
+	for hint != parse.LeaveHint {
+		if hint == parse.FieldHint {
+			// Skip over the index.
+			if hint, err = p.Next(); err != nil {
+				return err
+			}
+		}
+		if err := encode(w, p, hint); err != nil {
+			return err
+		}
+		if hint, err = p.Next(); err != nil {
+			return err
+		}
+	}
+	return w.WriteArrayEnd()
+}
+
+func encodeKey(w Writer, kind parse.Kind, name []byte) error {
+	switch kind {
+	case parse.StringKind:
+		return w.WriteKey(name)
+	case parse.Int64Kind:
+		var buf [20]byte
+		return w.WriteKey(strconv.AppendInt(buf[:0], cast.ToInt64(name), 10))
+	}
+	return ErrUnsupportedKind
+}
+
+func encodeToken(w Writer, kind parse.Kind, value []byte) error {
+	switch kind {
+	case parse.NullKind:
+		return w.WriteNull()
+	case parse.FalseKind:
+		return w.WriteBool(false)
+	case parse.TrueKind:
+		return w.WriteBool(true)
+	case parse.StringKind:
+		return w.WriteString(value)
+	case parse.BytesKind:
+		// Bytes are written as a base64 string, like encoding/json does.
+		return w.WriteString(base64.StdEncoding.AppendEncode(nil, value))
+	case parse.Int64Kind, parse.NanosecondsKind:
+		return w.WriteInt64(cast.ToInt64(value))
+	case parse.Float64Kind:
+		return w.WriteFloat64(cast.ToFloat64(value))
+	case parse.DecimalKind:
+		return w.WriteDecimal(value)
+	}
+	return ErrUnsupportedKind
+}
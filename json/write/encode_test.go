@@ -0,0 +1,132 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package write
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/katydid/parser-go-json/json"
+	jsonparse "github.com/katydid/parser-go-json/json/parse"
+	"github.com/katydid/parser-go-json/json/tag"
+	"github.com/katydid/parser-go/parse"
+)
+
+var encodeInputs = []string{
+	`null`,
+	`true`,
+	`"a\"b\u0001"`,
+	`-123`,
+	`3.14`,
+	`18446744073709551615`,
+	`1e400`,
+	`[]`,
+	`{}`,
+	`[[]]`,
+	`[{}]`,
+	`{"a":[]}`,
+	`{"a":{}}`,
+	`[1,"b",null,true,false]`,
+	`{"a":1,"b":[1,{"c":[[],{}]}],"d":{"e":"f"}}`,
+	`{"0":"zero","1":[0,1]}`,
+}
+
+func encodeString(t *testing.T, p parse.Parser) string {
+	t.Helper()
+	buf := bytes.NewBuffer(nil)
+	if err := Encode(buf, p); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestEncode(t *testing.T) {
+	parsers := map[string]func(input []byte) parse.Parser{
+		"parse": func(input []byte) parse.Parser {
+			return jsonparse.NewParser(jsonparse.WithBuffer(input))
+		},
+		"indexes": func(input []byte) parse.Parser {
+			p := json.NewParser()
+			p.Init(input)
+			return p
+		},
+		"jsonschema": func(input []byte) parse.Parser {
+			p := json.NewJSONSchemaParser()
+			p.Init(input)
+			return p
+		},
+		"tags": func(input []byte) parse.Parser {
+			return tag.NewTagger(jsonparse.NewParser(jsonparse.WithBuffer(input)), tag.WithTags())
+		},
+	}
+	for name, newParser := range parsers {
+		t.Run(name, func(t *testing.T) {
+			for _, input := range encodeInputs {
+				got := encodeString(t, newParser([]byte(input)))
+				if got != input {
+					t.Errorf("want %s, got %s", input, got)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeJSON5ExactNumbers(t *testing.T) {
+	p := jsonparse.NewParser(jsonparse.WithJSON5(), jsonparse.WithExactNumbers(), jsonparse.WithBuffer([]byte(`[.5, 5., +1, -.5e3, 0x10]`)))
+	if got, want := encodeString(t, p), `[0.5,5,1,-0.5e3,16]`; got != want {
+		t.Fatalf("want %s, but got %s", want, got)
+	}
+}
+
+func TestEncodeWhitespace(t *testing.T) {
+	p := json.NewJSONSchemaParser()
+	p.Init([]byte(" { \"a\" : [ 1 , 2 ] , \"b\" : { } } "))
+	want := `{"a":[1,2],"b":{}}`
+	if got := encodeString(t, p); got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+}
+
+// TestEncodeValue encodes each element of an array separately.
+func TestEncodeValue(t *testing.T) {
+	p := json.NewParser()
+	p.Init([]byte(`[{"a":1},[2],3]`))
+	hint, err := p.Next()
+	check(t, err)
+	if hint != parse.EnterHint {
+		t.Fatalf("want enter, got %v", hint)
+	}
+	want := []string{`{"a":1}`, `[2]`, `3`}
+	for i := range want {
+		hint, err := p.Next()
+		check(t, err)
+		if hint != parse.FieldHint {
+			t.Fatalf("want field, got %v", hint)
+		}
+		w := NewBufferWriter(nil)
+		check(t, EncodeValue(w, p))
+		if got := string(w.Bytes()); got != want[i] {
+			t.Fatalf("want %s, got %s", want[i], got)
+		}
+	}
+}
+
+func TestEncodeError(t *testing.T) {
+	p := json.NewParser()
+	p.Init([]byte(`{"a":[1,}`))
+	if err := Encode(bytes.NewBuffer(nil), p); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,41 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package write
+
+import "errors"
+
+// ErrExpectedKey is returned when a value is written in an object, where a key is expected.
+var ErrExpectedKey = errors.New("expected key")
+
+// ErrUnexpectedKey is returned when a key is written outside of an object, or directly after another key.
+var ErrUnexpectedKey = errors.New("unexpected key")
+
+// ErrUnexpectedEnd is returned when an object or array is ended, without being started, or before the value of its last key.
+var ErrUnexpectedEnd = errors.New("unexpected end of object or array")
+
+// ErrComplete is returned when anything is written after a whole JSON value has been written.
+var ErrComplete = errors.New("JSON value is already complete")
+
+// ErrNotFinite is returned when NaN or Infinity is written, which cannot be represented in JSON.
+var ErrNotFinite = errors.New("number is not finite")
+
+// ErrInvalidNumber is returned when a decimal is written, which is not a valid JSON number.
+var ErrInvalidNumber = errors.New("invalid number")
+
+// ErrUnexpectedHint is returned by Encode when the parser returns a hint that does not fit the structure of a JSON value.
+var ErrUnexpectedHint = errors.New("unexpected hint")
+
+// ErrUnsupportedKind is returned by Encode when the parser returns a token of a kind that cannot be written as JSON.
+var ErrUnsupportedKind = errors.New("unsupported kind")
@@ -0,0 +1,35 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package write
+
+// state mirrors the states of the parser.
+type state byte
+
+const startState = state(0)
+
+const arrayOpenState = state('[')
+
+// arrayElementState is for when an element has been written, so that the next element needs to be preceded by a ','.
+const arrayElementState = state(',')
+
+const objectOpenState = state('{')
+
+// objectKeyState is for when a key has been written, but not yet its value.
+const objectKeyState = state('k')
+
+// objectValueState is for when a value has been written, so that the next key needs to be preceded by a ','.
+const objectValueState = state('v')
+
+const endState = state('e')
@@ -0,0 +1,288 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package write writes JSON text without allocating, after it is warmed up,
+// and checks that the calls to the writer form a valid JSON value.
+package write
+
+import (
+	"io"
+	"math"
+
+	"github.com/katydid/parser-go-json/json/internal/fork/strconv"
+	"github.com/katydid/parser-go-json/json/scan"
+)
+
+// Writer writes JSON text, while it checks that objects and arrays are started, ended and filled in a valid order.
+// A call that would result in invalid JSON returns an error and writes nothing.
+type Writer interface {
+	// Init restarts the writer, so that it appends to dst, without allocating a new writer.
+	Init(dst []byte)
+	// InitWriter restarts the writer, so that it writes to w, without allocating a new writer.
+	// The JSON text is buffered and written to w once the buffer is full or once the whole JSON value has been written.
+	InitWriter(w io.Writer)
+
+	WriteObjectStart() error
+	WriteObjectEnd() error
+	WriteArrayStart() error
+	WriteArrayEnd() error
+	// WriteKey writes the key of the next field of an object as a quoted and escaped string.
+	WriteKey(key []byte) error
+	// WriteString writes a quoted and escaped string.
+	// Quotes, backslashes and control characters are escaped and invalid UTF-8 is replaced by the Unicode replacement character.
+	WriteString(s []byte) error
+	WriteInt64(i int64) error
+	WriteUint64(u uint64) error
+	// WriteFloat64 writes the shortest decimal that parses back to the same float64,
+	// using an exponent only for numbers smaller than 1e-6 or larger than or equal to 1e21, like ECMAScript.
+	// It returns ErrNotFinite for NaN and Infinity.
+	WriteFloat64(f float64) error
+	// WriteDecimal writes the exact text of a number, for example a parse.DecimalKind token.
+	// It returns ErrInvalidNumber if the text is not a valid JSON number.
+	WriteDecimal(number []byte) error
+	WriteBool(b bool) error
+	WriteNull() error
+
+	// Complete returns true if a whole JSON value has been written.
+	Complete() bool
+	// Bytes returns the JSON text that was appended to dst, or the text that has not been written to w yet.
+	Bytes() []byte
+	// Flush writes the buffered JSON text to w, if the writer was initialized with InitWriter.
+	Flush() error
+}
+
+type writer struct {
+	buf []byte
+	// w is only set if the writer was initialized with InitWriter.
+	w io.Writer
+	// window is the buffer that is reused, when the writer writes to w.
+	window []byte
+
+	state state
+	stack []state
+}
+
+// defaultBufferSize is the size of the buffer that is written to w, once it is full.
+const defaultBufferSize = 4096
+
+// NewWriter returns a Writer that writes to w.
+func NewWriter(w io.Writer) Writer {
+	wr := &writer{stack: make([]state, 0, 10)}
+	wr.InitWriter(w)
+	return wr
+}
+
+// NewBufferWriter returns a Writer that appends to dst.
+func NewBufferWriter(dst []byte) Writer {
+	wr := &writer{stack: make([]state, 0, 10)}
+	wr.Init(dst)
+	return wr
+}
+
+func (wr *writer) reset() {
+	wr.state = startState
+	wr.stack = wr.stack[:0]
+}
+
+// Init restarts the writer, so that it appends to dst.
+func (wr *writer) Init(dst []byte) {
+	wr.reset()
+	wr.buf = dst
+	wr.w = nil
+}
+
+// InitWriter restarts the writer, so that it writes to w.
+func (wr *writer) InitWriter(w io.Writer) {
+	wr.reset()
+	if wr.window == nil {
+		wr.window = make([]byte, 0, defaultBufferSize)
+	}
+	wr.buf = wr.window[:0]
+	wr.w = w
+}
+
+func (wr *writer) Complete() bool {
+	return wr.state == endState
+}
+
+func (wr *writer) Bytes() []byte {
+	return wr.buf
+}
+
+func (wr *writer) Flush() error {
+	if wr.w == nil || len(wr.buf) == 0 {
+		return nil
+	}
+	_, err := wr.w.Write(wr.buf)
+	wr.buf = wr.buf[:0]
+	return err
+}
+
+// value checks that a value may be written and writes the comma that precedes it.
+// The state is only changed by done, once the value has been written.
+func (wr *writer) value() error {
+	switch wr.state {
+	case startState, arrayOpenState, objectKeyState:
+		return nil
+	case arrayElementState:
+		wr.buf = append(wr.buf, ',')
+		return nil
+	case objectOpenState, objectValueState:
+		return ErrExpectedKey
+	case endState:
+		return ErrComplete
+	}
+	panic("unreachable")
+}
+
+// done moves to the next state, after a value has been written.
+func (wr *writer) done() error {
+	switch wr.state {
+	case startState:
+		wr.state = endState
+	case arrayOpenState, arrayElementState:
+		wr.state = arrayElementState
+	case objectKeyState:
+		wr.state = objectValueState
+	}
+	if len(wr.buf) >= defaultBufferSize || wr.state == endState {
+		return wr.Flush()
+	}
+	return nil
+}
+
+func (wr *writer) start(open byte, next state) error {
+	if err := wr.value(); err != nil {
+		return err
+	}
+	wr.buf = append(wr.buf, open)
+	wr.stack = append(wr.stack, wr.state)
+	wr.state = next
+	return nil
+}
+
+func (wr *writer) end(close byte, open, element state) error {
+	if (wr.state != open && wr.state != element) || len(wr.stack) == 0 {
+		return ErrUnexpectedEnd
+	}
+	wr.buf = append(wr.buf, close)
+	top := len(wr.stack) - 1
+	wr.state = wr.stack[top]
+	wr.stack = wr.stack[:top]
+	return wr.done()
+}
+
+func (wr *writer) WriteObjectStart() error {
+	return wr.start('{', objectOpenState)
+}
+
+func (wr *writer) WriteObjectEnd() error {
+	return wr.end('}', objectOpenState, objectValueState)
+}
+
+func (wr *writer) WriteArrayStart() error {
+	return wr.start('[', arrayOpenState)
+}
+
+func (wr *writer) WriteArrayEnd() error {
+	return wr.end(']', arrayOpenState, arrayElementState)
+}
+
+func (wr *writer) WriteKey(key []byte) error {
+	switch wr.state {
+	case objectOpenState:
+	case objectValueState:
+		wr.buf = append(wr.buf, ',')
+	case endState:
+		return ErrComplete
+	default:
+		return ErrUnexpectedKey
+	}
+	wr.buf = AppendString(wr.buf, key)
+	wr.buf = append(wr.buf, ':')
+	wr.state = objectKeyState
+	return nil
+}
+
+func (wr *writer) WriteString(s []byte) error {
+	if err := wr.value(); err != nil {
+		return err
+	}
+	wr.buf = AppendString(wr.buf, s)
+	return wr.done()
+}
+
+func (wr *writer) WriteInt64(i int64) error {
+	if err := wr.value(); err != nil {
+		return err
+	}
+	wr.buf = strconv.AppendInt(wr.buf, i, 10)
+	return wr.done()
+}
+
+func (wr *writer) WriteUint64(u uint64) error {
+	if err := wr.value(); err != nil {
+		return err
+	}
+	wr.buf = strconv.AppendUint(wr.buf, u, 10)
+	return wr.done()
+}
+
+func (wr *writer) WriteFloat64(f float64) error {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return ErrNotFinite
+	}
+	if err := wr.value(); err != nil {
+		return err
+	}
+	wr.buf = AppendFloat(wr.buf, f)
+	return wr.done()
+}
+
+func (wr *writer) WriteDecimal(number []byte) error {
+	if n, err := scan.Number(number); err != nil || n != len(number) {
+		return ErrInvalidNumber
+	}
+	if err := wr.value(); err != nil {
+		return err
+	}
+	wr.buf = append(wr.buf, number...)
+	return wr.done()
+}
+
+var trueBytes = []byte("true")
+
+var falseBytes = []byte("false")
+
+var nullBytes = []byte("null")
+
+func (wr *writer) WriteBool(b bool) error {
+	if err := wr.value(); err != nil {
+		return err
+	}
+	if b {
+		wr.buf = append(wr.buf, trueBytes...)
+	} else {
+		wr.buf = append(wr.buf, falseBytes...)
+	}
+	return wr.done()
+}
+
+func (wr *writer) WriteNull() error {
+	if err := wr.value(); err != nil {
+		return err
+	}
+	wr.buf = append(wr.buf, nullBytes...)
+	return wr.done()
+}
@@ -0,0 +1,246 @@
+//  Copyright 2025 Walter Schulze
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package write
+
+import (
+	"bytes"
+	gojson "encoding/json"
+	"math"
+	"math/rand"
+	"testing"
+	"unicode/utf8"
+)
+
+func check(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+var (
+	numKey  = []byte("num")
+	arrKey  = []byte("arr")
+	decimal = []byte("1.5e400")
+	objKey  = []byte("obj")
+	kKey    = []byte("k")
+	vString = []byte("v\"\n")
+	aKey    = []byte("a")
+	oKey    = []byte("o")
+)
+
+func writeExample(t *testing.T, w Writer) {
+	t.Helper()
+	check(t, w.WriteObjectStart())
+	check(t, w.WriteKey(numKey))
+	check(t, w.WriteFloat64(3.14))
+	check(t, w.WriteKey(arrKey))
+	check(t, w.WriteArrayStart())
+	check(t, w.WriteNull())
+	check(t, w.WriteBool(false))
+	check(t, w.WriteBool(true))
+	check(t, w.WriteInt64(-1))
+	check(t, w.WriteUint64(math.MaxUint64))
+	check(t, w.WriteDecimal(decimal))
+	check(t, w.WriteArrayEnd())
+	check(t, w.WriteKey(objKey))
+	check(t, w.WriteObjectStart())
+	check(t, w.WriteKey(kKey))
+	check(t, w.WriteString(vString))
+	check(t, w.WriteKey(aKey))
+	check(t, w.WriteArrayStart())
+	check(t, w.WriteArrayEnd())
+	check(t, w.WriteKey(oKey))
+	check(t, w.WriteObjectStart())
+	check(t, w.WriteObjectEnd())
+	check(t, w.WriteObjectEnd())
+	check(t, w.WriteObjectEnd())
+}
+
+const example = `{"num":3.14,"arr":[null,false,true,-1,18446744073709551615,1.5e400],"obj":{"k":"v\"\n","a":[],"o":{}}}`
+
+func TestWriter(t *testing.T) {
+	w := NewBufferWriter(nil)
+	writeExample(t, w)
+	if !w.Complete() {
+		t.Fatal("expected a complete value")
+	}
+	if got := string(w.Bytes()); got != example {
+		t.Fatalf("want %s, but got %s", example, got)
+	}
+	// Init appends to the given buffer.
+	w.Init([]byte("prefix "))
+	writeExample(t, w)
+	if got := string(w.Bytes()); got != "prefix "+example {
+		t.Fatalf("want prefixed %s, but got %s", example, got)
+	}
+}
+
+func TestWriterIOWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := NewWriter(&out)
+	writeExample(t, w)
+	if got := out.String(); got != example {
+		t.Fatalf("want %s, but got %s", example, got)
+	}
+	if len(w.Bytes()) != 0 {
+		t.Fatal("expected a complete value to be flushed")
+	}
+	// Values that are larger than the buffer are written while they are being written.
+	out.Reset()
+	w.InitWriter(&out)
+	want := NewBufferWriter(nil)
+	for _, w := range []Writer{w, want} {
+		check(t, w.WriteArrayStart())
+		for i := range 2 * defaultBufferSize {
+			check(t, w.WriteInt64(int64(i)))
+		}
+	}
+	if out.Len() == 0 {
+		t.Fatal("expected the full buffer to be flushed")
+	}
+	for _, w := range []Writer{w, want} {
+		check(t, w.WriteArrayEnd())
+	}
+	if out.String() != string(want.Bytes()) {
+		t.Fatal("output does not match")
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	w := NewBufferWriter(nil)
+	steps := []struct {
+		name  string
+		write func() error
+		want  error
+	}{
+		{"key outside object", func() error { return w.WriteKey([]byte("a")) }, ErrUnexpectedKey},
+		{"end before start", func() error { return w.WriteArrayEnd() }, ErrUnexpectedEnd},
+		{"start object", func() error { return w.WriteObjectStart() }, nil},
+		{"value instead of key", func() error { return w.WriteNull() }, ErrExpectedKey},
+		{"end array in object", func() error { return w.WriteArrayEnd() }, ErrUnexpectedEnd},
+		{"key", func() error { return w.WriteKey([]byte("a")) }, nil},
+		{"key after key", func() error { return w.WriteKey([]byte("b")) }, ErrUnexpectedKey},
+		{"end before value", func() error { return w.WriteObjectEnd() }, ErrUnexpectedEnd},
+		{"NaN", func() error { return w.WriteFloat64(math.NaN()) }, ErrNotFinite},
+		{"Infinity", func() error { return w.WriteFloat64(math.Inf(-1)) }, ErrNotFinite},
+		{"invalid decimal", func() error { return w.WriteDecimal([]byte("01")) }, ErrInvalidNumber},
+		{"empty decimal", func() error { return w.WriteDecimal(nil) }, ErrInvalidNumber},
+		{"value", func() error { return w.WriteInt64(1) }, nil},
+		{"value instead of next key", func() error { return w.WriteInt64(2) }, ErrExpectedKey},
+		{"end", func() error { return w.WriteObjectEnd() }, nil},
+		{"value after end", func() error { return w.WriteInt64(3) }, ErrComplete},
+		{"key after end", func() error { return w.WriteKey([]byte("c")) }, ErrComplete},
+		{"start after end", func() error { return w.WriteArrayStart() }, ErrComplete},
+		{"end after end", func() error { return w.WriteObjectEnd() }, ErrUnexpectedEnd},
+	}
+	for _, step := range steps {
+		if err := step.write(); err != step.want {
+			t.Fatalf("%s: want %v, but got %v", step.name, step.want, err)
+		}
+	}
+	// Failed calls did not write anything.
+	if got := string(w.Bytes()); got != `{"a":1}` {
+		t.Fatalf("want {\"a\":1}, but got %s", got)
+	}
+}
+
+func TestAppendString(t *testing.T) {
+	strs := map[string]string{
+		"":                  `""`,
+		"abc":               `"abc"`,
+		"a\"b\\c/":          `"a\"b\\c/"`,
+		"\b\t\n\f\r":        `"\b\t\n\f\r"`,
+		"\x00\x1f\x7f":      "\"\\u0000\\u001f\x7f\"",
+		"é€𝄞 <>&":           "\"é€𝄞 <>&\"",
+		"a\xffb":            "\"a�b\"",
+		"\xed\xa0\x80":      "\"���\"",
+		"\xe2\x82":          "\"��\"",
+		"tail\xc3":          "\"tail�\"",
+		"\xf0\x9d\x84\x9e!": "\"𝄞!\"",
+	}
+	for input, want := range strs {
+		if got := string(AppendString(nil, []byte(input))); got != want {
+			t.Fatalf("%q: want %s, but got %s", input, want, got)
+		}
+	}
+}
+
+func TestAppendStringRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 1000 {
+		bs := make([]byte, r.Intn(20))
+		for i := range bs {
+			bs[i] = byte(r.Intn(256))
+		}
+		quoted := AppendString(nil, bs)
+		var got string
+		if err := gojson.Unmarshal(quoted, &got); err != nil {
+			t.Fatalf("%q: %v", bs, err)
+		}
+		want := string(bytes.ToValidUTF8(bs, nil))
+		if utf8.Valid(bs) && got != want {
+			t.Fatalf("%q: got %q", bs, got)
+		}
+	}
+}
+
+func TestAppendFloat(t *testing.T) {
+	floats := []float64{
+		0, 1, -1, 0.1, 1.5, 3.14, 1e20, 1e21, 123456789e13, 1e-6, 1e-7, 1.5e-7,
+		math.MaxFloat64, math.SmallestNonzeroFloat64, -math.MaxFloat64, 5e-324, 9007199254740993,
+	}
+	r := rand.New(rand.NewSource(1))
+	for range 1000 {
+		floats = append(floats, math.Float64frombits(r.Uint64()))
+	}
+	for _, f := range floats {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			continue
+		}
+		want, err := gojson.Marshal(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := AppendFloat(nil, f); string(got) != string(want) {
+			t.Fatalf("%v: want %s, but got %s", f, want, got)
+		}
+	}
+}
+
+func TestWriterNoAllocs(t *testing.T) {
+	w := NewBufferWriter(nil)
+	buf := make([]byte, 0, 1024)
+	// Warm up the stack.
+	writeExample(t, w)
+	allocs := testing.AllocsPerRun(100, func() {
+		w.Init(buf[:0])
+		writeExample(t, w)
+	})
+	if allocs != 0 {
+		t.Fatalf("want no allocations, but got %v", allocs)
+	}
+	var out bytes.Buffer
+	out.Grow(1024)
+	w = NewWriter(&out)
+	allocs = testing.AllocsPerRun(100, func() {
+		out.Reset()
+		w.InitWriter(&out)
+		writeExample(t, w)
+	})
+	if allocs != 0 {
+		t.Fatalf("want no allocations when writing, but got %v", allocs)
+	}
+}